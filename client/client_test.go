@@ -1,27 +1,34 @@
 package main
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
 )
 
+type RedditPost = redditclient.RedditPost
+type RedditComment = redditclient.RedditComment
+
+// Mock of the calls demoFunc makes, the other methods are left unimplemented
 type MockRedditAPI struct {
+	redditclient.RedditAPI
 	mock.Mock
 }
 
-func (m *MockRedditAPI) GetPost(id int32) (*RedditPost, error) {
+func (m *MockRedditAPI) GetPost(ctx context.Context, id int32) (*RedditPost, error) {
 	args := m.Called(id)
 	return args.Get(0).(*RedditPost), args.Error(1)
 }
 
-func (m *MockRedditAPI) GetTopComments(postId int32, limit int32) ([]*RedditComment, error) {
+func (m *MockRedditAPI) GetTopComments(ctx context.Context, postId int32, limit int32) ([]*RedditComment, error) {
 	args := m.Called(postId, limit)
 	return args.Get(0).([]*RedditComment), args.Error(1)
 }
 
-func (m *MockRedditAPI) ExpandCommentBranch(commentId int32, limit int32) ([]*RedditComment, error) {
+func (m *MockRedditAPI) ExpandCommentBranch(ctx context.Context, commentId int32, limit int32) ([]*RedditComment, error) {
 	args := m.Called(commentId, limit)
 	return args.Get(0).([]*RedditComment), args.Error(1)
 }
@@ -36,10 +43,18 @@ func TestDemoFunc(t *testing.T) {
 	mockAPI.On("ExpandCommentBranch", int32(2), int32(10)).Return([]*RedditComment{{Content: "Test Content"}}, nil)
 
 	// Call the function
-	result, err := demoFunc(mockAPI)
+	result, err := demoFunc(context.Background(), mockAPI)
 	assert.NoError(t, err)
 	assert.Equal(t, "Test Content", result)
 
 	// Assert that all expectations were met
 	mockAPI.AssertExpectations(t)
 }
+
+func TestDemoFuncError(t *testing.T) {
+	mockAPI := new(MockRedditAPI)
+	mockAPI.On("GetPost", int32(1)).Return((*RedditPost)(nil), redditclient.ErrNotFound)
+
+	_, err := demoFunc(context.Background(), mockAPI)
+	assert.ErrorIs(t, err, redditclient.ErrNotFound)
+}
@@ -0,0 +1,515 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"golang.org/x/term"
+)
+
+// Dependencies of the subcommands
+type cli struct {
+	api     redditclient.RedditAPI
+	out     *printer
+	stderr  io.Writer
+	timeout time.Duration
+}
+
+type command struct {
+	usage string
+	run   func(c *cli, ctx context.Context, args []string) error
+}
+
+// Subcommands by "group action", or by name alone for ungrouped ones
+var commands = map[string]command{
+	"post create":    {"post create -subreddit ID -author ID [-video URL] [-image URL] TITLE CONTENT", (*cli).postCreate},
+	"post get":       {"post get ID", (*cli).postGet},
+	"post vote":      {"post vote [-down] [-voter ID] ID", (*cli).postVote},
+	"post list":      {"post list [-subreddit ID] [-size N] [-page TOKEN]", (*cli).postList},
+	"comment create": {"comment create -author ID (-post ID | -comment ID) CONTENT", (*cli).commentCreate},
+	"comment get":    {"comment get ID", (*cli).commentGet},
+	"comment vote":   {"comment vote [-down] [-voter ID] ID", (*cli).commentVote},
+	"comment tree":   {"comment tree [-top N] [-depth N] POST_ID", (*cli).commentTree},
+	"subreddit get":  {"subreddit get ID", (*cli).subRedditGet},
+	"subreddit list": {"subreddit list [-size N] [-page TOKEN]", (*cli).subRedditList},
+	"search":         {"search [-subreddit ID] [-limit N] QUERY", (*cli).search},
+	"watch":          {"watch [-post ID]... [-comment ID]...", (*cli).watch},
+	"tui":            {"tui [-user ID]", (*cli).tui},
+	"demo":           {"demo", (*cli).demo},
+	"loadgen":        {"loadgen [-workers N] [-duration D] [-requests N] [-streams N] [-mix OP=WEIGHT,...] [-post ID] [-subreddit ID] [-author ID]", (*cli).loadgen},
+}
+
+var errUsage = errors.New("invalid usage")
+
+// Run the subcommand named by the first arguments
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		args = []string{"demo"}
+	}
+	name := args[0]
+	args = args[1:]
+	if _, ok := commands[name]; !ok && len(args) > 0 {
+		name += " " + args[0]
+		args = args[1:]
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		c.usage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+	err := cmd.run(c, ctx, args)
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(c.stderr, "Usage: client [flags] %s\n", cmd.usage)
+	}
+	return err
+}
+
+func (c *cli) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, commands[name].usage)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(c.stderr, "Usage: client [flags] COMMAND\n\nCommands:\n  %s\n", strings.Join(names, "\n  "))
+}
+
+// Flags of a subcommand, which reports usage errors instead of exiting
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// Parse the flags and exactly n positional arguments
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if fs.NArg() != n {
+		return nil, errUsage
+	}
+	return fs.Args(), nil
+}
+
+func parseID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid id %q", s)
+	}
+	return int32(id), nil
+}
+
+// Parse the single ID argument of a subcommand
+func parseIDArg(fs *flag.FlagSet, args []string) (int32, error) {
+	rest, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return 0, err
+	}
+	return parseID(rest[0])
+}
+
+// Deadline of a unary subcommand
+func (c *cli) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+/**
+ *
+ * Posts
+ *
+ */
+
+func (c *cli) postCreate(ctx context.Context, args []string) error {
+	fs := c.flags("post create")
+	subRedditID := fs.Int("subreddit", 0, "The subreddit to post to")
+	authorID := fs.Int("author", 0, "The author of the post")
+	videoURL := fs.String("video", "", "The URL of a video attached to the post")
+	imageURL := fs.String("image", "", "The URL of an image attached to the post")
+	rest, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	var opts []redditclient.PostOption
+	if *videoURL != "" {
+		opts = append(opts, redditclient.WithVideoURL(*videoURL))
+	}
+	if *imageURL != "" {
+		opts = append(opts, redditclient.WithImageURL(*imageURL))
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	post, err := c.api.CreatePost(ctx, rest[0], rest[1], int32(*subRedditID), int32(*authorID), opts...)
+	if err != nil {
+		return err
+	}
+	return c.out.print(post)
+}
+
+func (c *cli) postGet(ctx context.Context, args []string) error {
+	id, err := parseIDArg(c.flags("post get"), args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	post, err := c.api.GetPost(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.out.print(post)
+}
+
+func (c *cli) postVote(ctx context.Context, args []string) error {
+	fs := c.flags("post vote")
+	down := fs.Bool("down", false, "Downvote instead of upvote")
+	voterID := fs.Int("voter", 0, "The user voting")
+	id, err := parseIDArg(fs, args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	score, err := c.api.VotePost(ctx, id, !*down, voterOptions(*voterID)...)
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.VotePostResponse{Score: score})
+}
+
+func (c *cli) postList(ctx context.Context, args []string) error {
+	fs := c.flags("post list")
+	subRedditID := fs.Int("subreddit", 0, "Only list posts of this subreddit")
+	size := fs.Int("size", 0, "The posts per page, the server's default when 0")
+	page := fs.String("page", "", "The page token printed with the previous page")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	posts, next, err := c.api.ListPosts(ctx, int32(*subRedditID), int32(*size), *page)
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.ListPostsResponse{Posts: posts, NextPageToken: next})
+}
+
+func (c *cli) search(ctx context.Context, args []string) error {
+	fs := c.flags("search")
+	subRedditID := fs.Int("subreddit", 0, "Only search posts of this subreddit")
+	limit := fs.Int("limit", 0, "The maximum posts found, the server's default when 0")
+	rest, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	posts, err := c.api.SearchPosts(ctx, rest[0], int32(*subRedditID), int32(*limit))
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.SearchPostsResponse{Posts: posts})
+}
+
+/**
+ *
+ * Comments
+ *
+ */
+
+func (c *cli) commentCreate(ctx context.Context, args []string) error {
+	fs := c.flags("comment create")
+	authorID := fs.Int("author", 0, "The author of the comment")
+	postID := fs.Int("post", 0, "The post to reply to")
+	commentID := fs.Int("comment", 0, "The comment to reply to")
+	rest, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if (*postID > 0) == (*commentID > 0) {
+		return errUsage
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var comment *redditclient.RedditComment
+	if *postID > 0 {
+		comment, err = c.api.ReplyToPost(ctx, int32(*postID), int32(*authorID), rest[0])
+	} else {
+		comment, err = c.api.ReplyToComment(ctx, int32(*commentID), int32(*authorID), rest[0])
+	}
+	if err != nil {
+		return err
+	}
+	return c.out.print(comment)
+}
+
+// Identify the voter unless the voter flag is unset
+func voterOptions(voterID int) []redditclient.VoteOption {
+	if voterID <= 0 {
+		return nil
+	}
+	return []redditclient.VoteOption{redditclient.WithVoter(int32(voterID))}
+}
+
+func (c *cli) commentGet(ctx context.Context, args []string) error {
+	id, err := parseIDArg(c.flags("comment get"), args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	comment, err := c.api.GetComment(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.out.print(comment)
+}
+
+func (c *cli) commentVote(ctx context.Context, args []string) error {
+	fs := c.flags("comment vote")
+	down := fs.Bool("down", false, "Downvote instead of upvote")
+	voterID := fs.Int("voter", 0, "The user voting")
+	id, err := parseIDArg(fs, args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	score, err := c.api.VoteComment(ctx, id, !*down, voterOptions(*voterID)...)
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.VoteCommentResponse{Score: score})
+}
+
+// Print the top comments of a post with their replies expanded
+func (c *cli) commentTree(ctx context.Context, args []string) error {
+	fs := c.flags("comment tree")
+	top := fs.Int("top", 10, "The most upvoted comments shown at each level")
+	depth := fs.Int("depth", 2, "The levels of replies expanded under the top comments")
+	postID, err := parseIDArg(fs, args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	comments, err := commentTree(ctx, c.api, postID, int32(*top), *depth)
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.GetTopCommentsResponse{Comments: comments})
+}
+
+// Top comments of a post, with the replies of each expanded depth levels deep
+func commentTree(ctx context.Context, s redditclient.RedditAPI, postID int32, quantity int32, depth int) ([]*redditclient.RedditComment, error) {
+	comments, err := s.GetTopComments(ctx, postID, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if err := expandReplies(ctx, s, comments, quantity, depth); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func expandReplies(ctx context.Context, s redditclient.RedditAPI, comments []*redditclient.RedditComment, quantity int32, depth int) error {
+	if depth <= 0 {
+		return nil
+	}
+	for _, comment := range comments {
+		replies, err := s.ExpandCommentBranch(ctx, comment.GetId(), quantity)
+		if err != nil {
+			return err
+		}
+		comment.Children = replies
+		for _, reply := range replies {
+			// Only the levels asked for, though the server may send more
+			reply.Children = nil
+		}
+		if err := expandReplies(ctx, s, replies, quantity, depth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ *
+ * Subreddits
+ *
+ */
+
+func (c *cli) subRedditGet(ctx context.Context, args []string) error {
+	id, err := parseIDArg(c.flags("subreddit get"), args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	subReddit, err := c.api.GetSubReddit(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.out.print(subReddit)
+}
+
+func (c *cli) subRedditList(ctx context.Context, args []string) error {
+	fs := c.flags("subreddit list")
+	size := fs.Int("size", 0, "The subreddits per page, the server's default when 0")
+	page := fs.String("page", "", "The page token printed with the previous page")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	subReddits, next, err := c.api.ListSubReddits(ctx, int32(*size), *page)
+	if err != nil {
+		return err
+	}
+	return c.out.print(&pb.ListSubRedditsResponse{SubReddits: subReddits, NextPageToken: next})
+}
+
+/**
+ *
+ * Streams
+ *
+ */
+
+// Repeatable flag of content IDs
+type idsFlag []int32
+
+func (f *idsFlag) String() string {
+	return fmt.Sprint(*f)
+}
+
+func (f *idsFlag) Set(s string) error {
+	id, err := parseID(s)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, id)
+	return nil
+}
+
+// Print score updates until interrupted, reconnecting when the stream breaks
+func (c *cli) watch(ctx context.Context, args []string) error {
+	fs := c.flags("watch")
+	var postIDs, commentIDs idsFlag
+	fs.Var(&postIDs, "post", "A post to watch, repeatable")
+	fs.Var(&commentIDs, "comment", "A comment to watch, repeatable")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if len(postIDs)+len(commentIDs) == 0 {
+		return errUsage
+	}
+
+	sub := c.api.Subscribe(ctx)
+	defer sub.Close()
+	for _, id := range postIDs {
+		sub.Add(redditclient.ContentPost, id)
+	}
+	for _, id := range commentIDs {
+		sub.Add(redditclient.ContentComment, id)
+	}
+
+	c.out.streaming = true
+	for event := range sub.Updates() {
+		if event.Resumed {
+			fmt.Fprintln(c.stderr, "Reconnected, updates may have been missed")
+			continue
+		}
+		if err := c.out.print(event.Update); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		// Interrupted
+		return nil
+	}
+	return sub.Err()
+}
+
+// Browse in the terminal, which is switched to raw mode and the alternate
+// screen until the TUI quits
+func (c *cli) tui(ctx context.Context, args []string) error {
+	fs := c.flags("tui")
+	userID := fs.Int("user", 1, "The user voting and replying")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return errors.New("tui needs a terminal")
+	}
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(in, state)
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l")
+	defer os.Stdout.WriteString("\x1b[?25h\x1b[?1049l")
+
+	size := func() (int, int) {
+		width, height, err := term.GetSize(out)
+		if err != nil || width <= 0 || height <= 0 {
+			return 80, 24
+		}
+		return width, height
+	}
+	return newTUI(c.api, int32(*userID), c.timeout).run(ctx, os.Stdin, os.Stdout, size)
+}
+
+func (c *cli) demo(ctx context.Context, args []string) error {
+	if _, err := parseArgs(c.flags("demo"), args, 0); err != nil {
+		return err
+	}
+	return runDemo(ctx, c.api, c.timeout)
+}
+
+// Drive load against the server and report its throughput and latency
+func (c *cli) loadgen(ctx context.Context, args []string) error {
+	fs := c.flags("loadgen")
+	workers := fs.Int("workers", 10, "The number of concurrent workers")
+	duration := fs.Duration("duration", 10*time.Second, "How long to run, 0 for no limit")
+	requests := fs.Int("requests", 0, "Stop after this many operations, 0 for no limit")
+	streams := fs.Int("streams", 0, "The MonitorUpdates streams held open during the run")
+	mix := fs.String("mix", "create=1,vote=5,get=10,top=3,monitor=1", "The relative weights of the create, vote, get, top and monitor operations")
+	postID := fs.Int("post", 1, "The post voted on, read and monitored")
+	subRedditID := fs.Int("subreddit", 1, "The subreddit posts are created in")
+	authorID := fs.Int("author", 1, "The author of created posts and the voter")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if *workers <= 0 || *duration < 0 || *requests < 0 || *streams < 0 {
+		return errUsage
+	}
+	parsed, err := parseMix(*mix)
+	if err != nil {
+		return err
+	}
+
+	g := &loadGenerator{api: c.api, timeout: c.timeout, config: loadgenConfig{
+		workers:     *workers,
+		duration:    *duration,
+		requests:    *requests,
+		streams:     *streams,
+		mix:         parsed,
+		postID:      int32(*postID),
+		subRedditID: int32(*subRedditID),
+		authorID:    int32(*authorID),
+	}}
+	return g.run(ctx).write(c.out.w, c.out.format)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomy0000000/grpc-reddit/reddit/fakereddit"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+)
+
+// Run a command against api, returning its output
+func runCLI(t *testing.T, api redditclient.RedditAPI, format string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	out, err := newPrinter(&stdout, format)
+	require.NoError(t, err)
+	c := &cli{api: api, out: out, stderr: &stderr}
+	err = c.run(context.Background(), args)
+	return stdout.String(), err
+}
+
+func TestCommandOutput(t *testing.T) {
+	client := fakereddit.NewClient(t, fakereddit.New(fakereddit.DefaultFixtures()))
+
+	out, err := runCLI(t, client, "table", "post", "get", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "ID  SCORE  SUBREDDIT  AUTHOR  TITLE\n1   2      1          1       Cat Video\n", out)
+
+	out, err = runCLI(t, client, "json", "post", "get", "1")
+	require.NoError(t, err)
+	var post map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &post))
+	assert.Equal(t, "Cat Video", post["title"])
+	assert.Equal(t, map[string]any{"id": 1.0}, post["subReddit"])
+
+	out, err = runCLI(t, client, "yaml", "post", "get", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "title: Cat Video\n")
+	assert.Contains(t, out, "subReddit:\n  id: 1\n")
+
+	out, err = runCLI(t, client, "table", "post", "list", "-size", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Cat Video\nNext page: -page 1\n")
+}
+
+func TestCommandWrites(t *testing.T) {
+	s := fakereddit.New(fakereddit.DefaultFixtures())
+	client := fakereddit.NewClient(t, s)
+
+	_, err := runCLI(t, client, "table", "post", "create", "-subreddit", "1", "-author", "2", "-image", "https://example.com/dog.png", "Dog", "Woof")
+	require.NoError(t, err)
+	require.Len(t, s.CreatedPosts(), 1)
+	assert.Equal(t, "https://example.com/dog.png", s.CreatedPosts()[0].GetImageURL())
+
+	_, err = runCLI(t, client, "table", "comment", "create", "-author", "2", "-comment", "3", "Agreed")
+	require.NoError(t, err)
+	require.Len(t, s.CreatedComments(), 1)
+	assert.Equal(t, redditclient.ContentComment, s.CreatedComments()[0].GetParent())
+
+	out, err := runCLI(t, client, "table", "comment", "vote", "-down", "-voter", "2", "3")
+	require.NoError(t, err)
+	assert.Equal(t, "SCORE\n-1\n", out)
+	assert.Equal(t, int32(2), s.Votes()[0].VoterID)
+}
+
+func TestCommentTree(t *testing.T) {
+	client := fakereddit.NewClient(t, fakereddit.New(fakereddit.DefaultFixtures()))
+
+	out, err := runCLI(t, client, "table", "comment", "tree", "-top", "5", "-depth", "1", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Fermentum aliquet adipiscing\n")
+	assert.Contains(t, out, "  Massa a litora\n")
+	assert.NotContains(t, out, "Aliquet sodales hendrerit", "only one level of replies")
+}
+
+func TestCommandUsage(t *testing.T) {
+	_, err := runCLI(t, new(MockRedditAPI), "table", "post", "frobnicate")
+	assert.ErrorContains(t, err, `unknown command "post frobnicate"`)
+
+	_, err = runCLI(t, new(MockRedditAPI), "table", "post", "get", "one")
+	assert.ErrorContains(t, err, `invalid id "one"`)
+
+	_, err = runCLI(t, new(MockRedditAPI), "table", "comment", "create", "-post", "1", "-comment", "2", "Hi")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = newPrinter(&bytes.Buffer{}, "xml")
+	assert.Error(t, err)
+}
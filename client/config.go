@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProfileName = "default"
+	tokenEnv           = "REDDIT_TOKEN"
+)
+
+// Server connection and output settings, read from a named profile of the
+// config file and overridden by flags
+type profile struct {
+	Address            string `yaml:"address"`
+	TLS                bool   `yaml:"tls"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerHostOverride string `yaml:"server_host_override"`
+	Token              string `yaml:"token"`
+	Output             string `yaml:"output"`
+}
+
+type config struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]profile `yaml:"profiles"`
+}
+
+// Config file used unless the config flag is set
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "reddit", "config.yaml")
+}
+
+// Read the config file. A missing file is an empty config unless required.
+func loadConfig(file string, required bool) (*config, error) {
+	c := &config{}
+	if file == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	return c, nil
+}
+
+// Select the named profile, or the current one of the config when name is
+// empty, on top of the defaults
+func (c *config) profile(name string) (profile, error) {
+	p := profile{Address: "localhost:50051", Output: "table"}
+	explicit := name != ""
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" {
+		name = defaultProfileName
+	}
+	selected, ok := c.Profiles[name]
+	if !ok {
+		if explicit {
+			return p, fmt.Errorf("unknown profile %q", name)
+		}
+		return p, nil
+	}
+
+	if selected.Address != "" {
+		p.Address = selected.Address
+	}
+	if selected.Output != "" {
+		p.Output = selected.Output
+	}
+	p.TLS = selected.TLS
+	p.CAFile = selected.CAFile
+	p.CertFile = selected.CertFile
+	p.KeyFile = selected.KeyFile
+	p.ServerHostOverride = selected.ServerHostOverride
+	p.Token = selected.Token
+	return p, nil
+}
+
+// Override the profile with the flags set on the command line, and take the
+// token from the environment unless a flag sets it
+func (p *profile) applyFlags(set map[string]bool) error {
+	if set["addr"] || set["port"] {
+		host, portString, err := net.SplitHostPort(p.Address)
+		if err != nil {
+			return fmt.Errorf("invalid profile address %q: %w", p.Address, err)
+		}
+		if set["addr"] {
+			host = *addr
+		}
+		if set["port"] {
+			portString = strconv.Itoa(*port)
+		}
+		p.Address = net.JoinHostPort(host, portString)
+	}
+	if set["tls"] {
+		p.TLS = *useTLS
+	}
+	if set["ca_file"] {
+		p.CAFile = *caFile
+	}
+	if set["cert_file"] {
+		p.CertFile = *certFile
+	}
+	if set["key_file"] {
+		p.KeyFile = *keyFile
+	}
+	if set["server_host_override"] {
+		p.ServerHostOverride = *serverHostOverride
+	}
+	if set["output"] {
+		p.Output = *output
+	}
+	if token := os.Getenv(tokenEnv); token != "" {
+		p.Token = token
+	}
+	if set["token"] {
+		p.Token = *token
+	}
+	return nil
+}
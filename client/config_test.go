@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigProfiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+current_profile: dev
+profiles:
+  dev:
+    address: dev.example.com:50051
+    tls: true
+    token: dev-token
+    output: json
+`), 0o600))
+
+	cfg, err := loadConfig(file, true)
+	require.NoError(t, err)
+	p, err := cfg.profile("")
+	require.NoError(t, err)
+	assert.Equal(t, profile{Address: "dev.example.com:50051", TLS: true, Token: "dev-token", Output: "json"}, p)
+
+	// Flags and the environment override the profile
+	t.Setenv(tokenEnv, "env-token")
+	*port = 443
+	t.Cleanup(func() { *port = 50051 })
+	require.NoError(t, p.applyFlags(map[string]bool{"port": true}))
+	assert.Equal(t, "dev.example.com:443", p.Address)
+	assert.Equal(t, "env-token", p.Token)
+
+	_, err = cfg.profile("prod")
+	assert.ErrorContains(t, err, `unknown profile "prod"`)
+
+	// Missing files are only an error when named explicitly
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), true)
+	assert.Error(t, err)
+	cfg, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"), false)
+	require.NoError(t, err)
+	p, err = cfg.profile("")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:50051", p.Address)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+)
+
+// Run demoFunc and then every individual demo function, each with its own
+// deadline
+func runDemo(ctx context.Context, s redditclient.RedditAPI, timeout time.Duration) error {
+	slog.Info("Demo started")
+	demoCtx, cancel := context.WithTimeout(ctx, timeout)
+	result, err := demoFunc(demoCtx, s)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("demo: %w", err)
+	}
+	slog.Info("Demo finished", slog.String("result", result))
+
+	steps := []struct {
+		name string
+		run  func(context.Context, redditclient.RedditAPI) error
+	}{
+		{"CreatePost", runCreatePost},
+		{"VotePost", runVotePost},
+		{"GetPost", runGetPost},
+		{"CreateComment", runCreateComment},
+		{"VoteComment", runVoteComment},
+		{"GetComment", runGetComment},
+		{"GetTopComments", runGetTopComments},
+		{"ExpandCommentBranch", runExpandCommentBranch},
+		{"MonitorUpdates", runMonitorUpdates},
+	}
+	failed := 0
+	for _, step := range steps {
+		stepCtx, cancel := context.WithTimeout(ctx, timeout)
+		if err := step.run(stepCtx, s); err != nil {
+			slog.Error("Demo step failed", slog.String("step", step.name), slog.Any("error", err))
+			failed++
+		}
+		cancel()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d demo steps failed", failed, len(steps))
+	}
+	return nil
+}
+
+/**
+ *
+ * Individual demo functions
+ *
+ */
+
+func runCreatePost(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.CreatePost(ctx, "Hello", "World", 1, 1)
+	return err
+}
+
+func runVotePost(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.VotePost(ctx, 1, true)
+	return err
+}
+
+func runGetPost(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.GetPost(ctx, 1)
+	return err
+}
+
+func runCreateComment(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.ReplyToPost(ctx, 1, 1, "Hello World")
+	return err
+}
+
+func runVoteComment(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.VoteComment(ctx, 1, true)
+	return err
+}
+
+func runGetComment(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.GetComment(ctx, 1)
+	return err
+}
+
+func runGetTopComments(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.GetTopComments(ctx, 2, 10)
+	return err
+}
+
+func runExpandCommentBranch(ctx context.Context, s redditclient.RedditAPI) error {
+	_, err := s.ExpandCommentBranch(ctx, 1, 10)
+	return err
+}
+
+// Monitor updates to posts and comments
+func runMonitorUpdates(ctx context.Context, s redditclient.RedditAPI) error {
+	sub := s.Subscribe(ctx)
+	defer sub.Close()
+
+	// Routine to receive updates
+	waitc := make(chan struct{})
+	go func() {
+		defer close(waitc)
+		for event := range sub.Updates() {
+			if event.Resumed {
+				slog.Info("Reconnected, updates may have been missed")
+				continue
+			}
+			slog.Debug("Received update", slog.Any("update", event.Update))
+		}
+	}()
+
+	// Monitor the first post
+	sub.Add(redditclient.ContentPost, 1)
+
+	// Wait for 10 seconds
+	if err := sleep(ctx, 10*time.Second); err != nil {
+		return err
+	}
+
+	// Switch to the first comment
+	sub.Remove(redditclient.ContentPost, 1)
+	sub.Add(redditclient.ContentComment, 1)
+
+	// Close the subscription after 10 seconds
+	if err := sleep(ctx, 10*time.Second); err != nil {
+		return err
+	}
+	sub.Close()
+	<-waitc
+	return sub.Err()
+}
+
+// Wait for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
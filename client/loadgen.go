@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Operations of the load generator, in report order
+var loadOps = []string{"create", "vote", "get", "top", "monitor"}
+
+// Relative weights of the operations each worker picks from
+type loadMix struct {
+	ops     []string
+	weights []int
+	total   int
+}
+
+// Parse a mix such as "get=10,vote=5,create=1"
+func parseMix(s string) (loadMix, error) {
+	var mix loadMix
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		op, value, ok := strings.Cut(entry, "=")
+		weight, err := strconv.Atoi(value)
+		if !ok || err != nil || weight < 0 {
+			return loadMix{}, fmt.Errorf("invalid mix entry %q, want op=weight", entry)
+		}
+		if !contains(loadOps, op) {
+			return loadMix{}, fmt.Errorf("unknown operation %q, want one of %s", op, strings.Join(loadOps, ", "))
+		}
+		mix.ops = append(mix.ops, op)
+		mix.weights = append(mix.weights, weight)
+		mix.total += weight
+	}
+	if mix.total == 0 {
+		return loadMix{}, fmt.Errorf("mix %q has no operation with a positive weight", s)
+	}
+	return mix, nil
+}
+
+func (m loadMix) pick(r *rand.Rand) string {
+	n := r.Intn(m.total)
+	for i, weight := range m.weights {
+		if n < weight {
+			return m.ops[i]
+		}
+		n -= weight
+	}
+	return m.ops[len(m.ops)-1]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings of a load generator run
+type loadgenConfig struct {
+	workers  int
+	duration time.Duration
+	requests int // Stop after this many operations, unless 0
+	streams  int // MonitorUpdates streams held open during the run
+	mix      loadMix
+
+	// Content the operations act on
+	postID      int32
+	subRedditID int32
+	authorID    int32
+}
+
+// Results of one operation type
+type opStats struct {
+	latencies []time.Duration
+	errors    map[string]int // By status code
+}
+
+// Results of the streams held open during a run
+type streamStats struct {
+	Opened  int `json:"opened" yaml:"opened"`
+	Updates int `json:"updates" yaml:"updates"`
+	Errors  int `json:"errors" yaml:"errors"`
+}
+
+type loadGenerator struct {
+	api     redditclient.RedditAPI
+	config  loadgenConfig
+	timeout time.Duration
+
+	mu      sync.Mutex
+	ops     map[string]*opStats
+	streams streamStats
+}
+
+// Drive the configured mix of operations until the duration passes, the
+// requests are done or ctx is cancelled
+func (g *loadGenerator) run(ctx context.Context) *loadReport {
+	g.ops = map[string]*opStats{}
+	ctx, cancel := context.WithCancel(ctx)
+	if g.config.duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, g.config.duration)
+	}
+	defer cancel()
+
+	// Open the streams before the operations start
+	var streams, opened sync.WaitGroup
+	for i := 0; i < g.config.streams; i++ {
+		streams.Add(1)
+		opened.Add(1)
+		go func() {
+			defer streams.Done()
+			g.holdStream(ctx, opened.Done)
+		}()
+	}
+	opened.Wait()
+
+	// Operations left to hand out, when the run is limited by count
+	var remaining chan struct{}
+	if g.config.requests > 0 {
+		remaining = make(chan struct{}, g.config.requests)
+		for i := 0; i < g.config.requests; i++ {
+			remaining <- struct{}{}
+		}
+		close(remaining)
+	}
+
+	start := time.Now()
+	var workers sync.WaitGroup
+	for i := 0; i < g.config.workers; i++ {
+		workers.Add(1)
+		go func(seed int64) {
+			defer workers.Done()
+			r := rand.New(rand.NewSource(seed))
+			for ctx.Err() == nil {
+				if remaining != nil {
+					if _, ok := <-remaining; !ok {
+						return
+					}
+				}
+				g.do(ctx, g.config.mix.pick(r), r)
+			}
+		}(start.UnixNano() + int64(i))
+	}
+	workers.Wait()
+	elapsed := time.Since(start)
+
+	cancel()
+	streams.Wait()
+	return g.report(elapsed)
+}
+
+// Run one operation and record its latency, or its error
+func (g *loadGenerator) do(ctx context.Context, op string, r *rand.Rand) {
+	callCtx, cancel := context.WithCancel(ctx)
+	if g.timeout > 0 {
+		callCtx, cancel = context.WithTimeout(ctx, g.timeout)
+	}
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	switch op {
+	case "create":
+		_, err = g.api.CreatePost(callCtx, "Load test", "Created by loadgen", g.config.subRedditID, g.config.authorID)
+	case "vote":
+		_, err = g.api.VotePost(callCtx, g.config.postID, r.Intn(2) == 0, redditclient.WithVoter(g.config.authorID))
+	case "get":
+		_, err = g.api.GetPost(callCtx, g.config.postID)
+	case "top":
+		_, err = g.api.GetTopComments(callCtx, g.config.postID, 10)
+	case "monitor":
+		err = g.monitorOnce(callCtx)
+	}
+	latency := time.Since(start)
+
+	if ctx.Err() != nil {
+		// Cut short by the end of the run
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	stats := g.ops[op]
+	if stats == nil {
+		stats = &opStats{errors: map[string]int{}}
+		g.ops[op] = stats
+	}
+	if err != nil {
+		stats.errors[status.Code(err).String()]++
+		return
+	}
+	stats.latencies = append(stats.latencies, latency)
+}
+
+// Open a stream, monitor the post and wait for its first update
+func (g *loadGenerator) monitorOnce(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := g.api.MonitorUpdates(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&redditclient.UpdateRequest{ContentType: redditclient.ContentPost, ContentID: g.config.postID}); err != nil {
+		return err
+	}
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return stream.CloseSend()
+}
+
+// Keep a stream monitoring the post open until the run ends, counting the
+// updates it receives. opened is called once the stream is open or failed.
+func (g *loadGenerator) holdStream(ctx context.Context, opened func()) {
+	stream, err := g.api.MonitorUpdates(ctx)
+	if err == nil {
+		err = stream.Send(&redditclient.UpdateRequest{ContentType: redditclient.ContentPost, ContentID: g.config.postID})
+	}
+	if err == nil {
+		// The first update shows the server accepted the subscription
+		_, err = stream.Recv()
+	}
+	if err != nil {
+		opened()
+		if ctx.Err() == nil {
+			g.mu.Lock()
+			g.streams.Errors++
+			g.mu.Unlock()
+		}
+		return
+	}
+
+	g.mu.Lock()
+	g.streams.Opened++
+	g.streams.Updates++
+	g.mu.Unlock()
+	opened()
+	for {
+		_, err := stream.Recv()
+		g.mu.Lock()
+		if err != nil {
+			if ctx.Err() == nil {
+				g.streams.Errors++
+			}
+			g.mu.Unlock()
+			return
+		}
+		g.streams.Updates++
+		g.mu.Unlock()
+	}
+}
+
+/**
+ *
+ * Report
+ *
+ */
+
+// Latency percentiles, in milliseconds
+type latencyReport struct {
+	Mean float64 `json:"mean" yaml:"mean"`
+	P50  float64 `json:"p50" yaml:"p50"`
+	P90  float64 `json:"p90" yaml:"p90"`
+	P99  float64 `json:"p99" yaml:"p99"`
+	Max  float64 `json:"max" yaml:"max"`
+}
+
+type opReport struct {
+	Op         string         `json:"op" yaml:"op"`
+	Requests   int            `json:"requests" yaml:"requests"`
+	Errors     map[string]int `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Throughput float64        `json:"throughput" yaml:"throughput"`
+	LatencyMS  latencyReport  `json:"latency_ms" yaml:"latency_ms"`
+}
+
+// Results of a load generator run, with throughput in operations per second
+type loadReport struct {
+	Workers    int          `json:"workers" yaml:"workers"`
+	Seconds    float64      `json:"seconds" yaml:"seconds"`
+	Requests   int          `json:"requests" yaml:"requests"`
+	Errors     int          `json:"errors" yaml:"errors"`
+	Throughput float64      `json:"throughput" yaml:"throughput"`
+	Ops        []opReport   `json:"ops" yaml:"ops"`
+	Streams    *streamStats `json:"streams,omitempty" yaml:"streams,omitempty"`
+}
+
+func (g *loadGenerator) report(elapsed time.Duration) *loadReport {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	seconds := elapsed.Seconds()
+	report := &loadReport{Workers: g.config.workers, Seconds: seconds}
+	var all []time.Duration
+	for _, op := range loadOps {
+		stats := g.ops[op]
+		if stats == nil {
+			continue
+		}
+		errors := 0
+		for _, n := range stats.errors {
+			errors += n
+		}
+		requests := len(stats.latencies) + errors
+		report.Ops = append(report.Ops, opReport{
+			Op:         op,
+			Requests:   requests,
+			Errors:     stats.errors,
+			Throughput: float64(requests) / seconds,
+			LatencyMS:  latencies(stats.latencies),
+		})
+		report.Requests += requests
+		report.Errors += errors
+		all = append(all, stats.latencies...)
+	}
+	report.Throughput = float64(report.Requests) / seconds
+	report.Ops = append(report.Ops, opReport{Op: "total", Requests: report.Requests, Throughput: report.Throughput, LatencyMS: latencies(all)})
+	if g.config.streams > 0 {
+		streams := g.streams
+		report.Streams = &streams
+	}
+	return report
+}
+
+// Percentiles of the successful calls, by the nearest rank
+func latencies(values []time.Duration) latencyReport {
+	if len(values) == 0 {
+		return latencyReport{}
+	}
+	sorted := append([]time.Duration{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) float64 {
+		rank := int(p*float64(len(sorted))+0.5) - 1
+		return ms(sorted[min(max(rank, 0), len(sorted)-1)])
+	}
+	var sum time.Duration
+	for _, v := range sorted {
+		sum += v
+	}
+	return latencyReport{
+		Mean: ms(sum / time.Duration(len(sorted))),
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+		P99:  percentile(0.99),
+		Max:  ms(sorted[len(sorted)-1]),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// Write the report as text for the table output format, or as JSON or YAML
+func (r *loadReport) write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	fmt.Fprintf(w, "%d requests in %.2fs with %d workers, %.1f/s, %d errors\n\n", r.Requests, r.Seconds, r.Workers, r.Throughput, r.Errors)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "OP\tREQUESTS\tERRORS\tRATE/S\tMEAN\tP50\tP90\tP99\tMAX")
+	for _, op := range r.Ops {
+		errors := 0
+		for _, n := range op.Errors {
+			errors += n
+		}
+		if op.Op == "total" {
+			errors = r.Errors
+		}
+		l := op.LatencyMS
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.2fms\t%.2fms\t%.2fms\t%.2fms\t%.2fms\n", op.Op, op.Requests, errors, op.Throughput, l.Mean, l.P50, l.P90, l.P99, l.Max)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if r.Errors > 0 {
+		fmt.Fprintln(w, "\nErrors:")
+		for _, op := range r.Ops {
+			codes := make([]string, 0, len(op.Errors))
+			for code := range op.Errors {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				fmt.Fprintf(w, "  %s %s: %d\n", op.Op, code, op.Errors[code])
+			}
+		}
+	}
+	if r.Streams != nil {
+		fmt.Fprintf(w, "\nStreams: %d opened, %d updates, %d errors\n", r.Streams.Opened, r.Streams.Updates, r.Streams.Errors)
+	}
+	return nil
+}
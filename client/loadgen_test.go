@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomy0000000/grpc-reddit/reddit/fakereddit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadgen(t *testing.T) {
+	s := fakereddit.New(fakereddit.DefaultFixtures())
+	s.FailNth("GetPost", 1, status.Error(codes.NotFound, "gone"))
+	client := fakereddit.NewClient(t, s)
+
+	out, err := runCLI(t, client, "json", "loadgen", "-workers", "4", "-requests", "60", "-streams", "2", "-mix", "get=1,vote=1,monitor=1,create=0", "-voter", "2")
+	assert.ErrorIs(t, err, errUsage, "unknown flag")
+	assert.Empty(t, out)
+
+	out, err = runCLI(t, client, "json", "loadgen", "-workers", "4", "-requests", "60", "-streams", "2", "-mix", "get=1,vote=1,monitor=1,create=0")
+	require.NoError(t, err)
+	var report loadReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, 60, report.Requests)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 2, report.Streams.Opened)
+	assert.Empty(t, s.CreatedPosts())
+	assert.Equal(t, len(s.Votes()), s.Calls("VotePost"))
+
+	ops := map[string]opReport{}
+	for _, op := range report.Ops {
+		ops[op.Op] = op
+	}
+	assert.Equal(t, map[string]int{"NotFound": 1}, ops["get"].Errors)
+	assert.Equal(t, s.Calls("GetPost"), ops["get"].Requests)
+	assert.Equal(t, 60, ops["total"].Requests)
+	assert.LessOrEqual(t, ops["total"].LatencyMS.P50, ops["total"].LatencyMS.P99)
+}
+
+func TestLoadgenText(t *testing.T) {
+	client := fakereddit.NewClient(t, fakereddit.New(fakereddit.DefaultFixtures()))
+	out, err := runCLI(t, client, "table", "loadgen", "-workers", "1", "-requests", "3", "-mix", "top=1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "3 requests in ")
+	assert.Contains(t, out, "OP     REQUESTS  ERRORS")
+	assert.Regexp(t, `\ntop +3 +0 `, out)
+	assert.Regexp(t, `\ntotal +3 +0 `, out)
+}
+
+func TestParseMix(t *testing.T) {
+	mix, err := parseMix("get=3, vote=1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"get", "vote"}, mix.ops)
+	assert.Equal(t, 4, mix.total)
+
+	_, err = parseMix("get=3,delete=1")
+	assert.ErrorContains(t, err, `unknown operation "delete"`)
+	_, err = parseMix("get")
+	assert.ErrorContains(t, err, "want op=weight")
+	_, err = parseMix("get=0")
+	assert.ErrorContains(t, err, "no operation with a positive weight")
+
+	_, err = runCLI(t, new(MockRedditAPI), "table", "loadgen", "-workers", "0")
+	assert.ErrorIs(t, err, errUsage)
+}
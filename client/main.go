@@ -1,42 +1,80 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/fatih/color"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
 )
 
+// Longest metrics scrapers may take to send request headers
+const metricsReadHeaderTimeout = 10 * time.Second
+
 var (
 	addr = flag.String("addr", "localhost", "the address to connect to")
 	port = flag.Int("port", 50051, "The server port")
+
+	useTLS             = flag.Bool("tls", false, "Connection uses TLS if true, else plain TCP")
+	caFile             = flag.String("ca_file", "", "The CA file to verify the server cert against, defaults to the system roots")
+	certFile           = flag.String("cert_file", "", "The client cert file, presented for mutual TLS")
+	keyFile            = flag.String("key_file", "", "The client key file")
+	serverHostOverride = flag.String("server_host_override", "", "The server name used to verify the hostname returned by the TLS handshake")
+
+	logLevel        = flag.String("log_level", "info", "The minimum log level: debug, info, warn or error")
+	logFormat       = flag.String("log_format", "text", "The log output format: text or json")
+	logFile         = flag.String("log_file", "", "The file logs are appended to, stderr by default or discarded by the tui and loadgen commands")
+	logPayloadLimit = flag.Int("log_payload_limit", 1024, "The maximum bytes of a payload logged at debug level, 0 for no limit")
+
+	traceExporter = flag.String("trace_exporter", "none", "The OpenTelemetry trace exporter: none, stdout or otlp")
+	otlpEndpoint  = flag.String("otlp_endpoint", "localhost:4317", "The OTLP gRPC collector address used by the otlp trace exporter")
+
+	configFile  = flag.String("config", defaultConfigFile(), "The config file of server profiles")
+	profileName = flag.String("profile", "", "The profile of the config file to use, its current_profile by default")
+	token       = flag.String("token", "", "The bearer token sent with every call, $"+tokenEnv+" by default")
+	output      = flag.String("output", "table", "The output format: table, json or yaml")
+
+	timeout = flag.Duration("timeout", 30*time.Second, "The deadline of each call, or of each demo step")
+	hedging = flag.Bool("hedging", false, "Send hedged attempts of reads slower than 50ms instead of only retrying failed ones")
+
+	metricsAddr = flag.String("metrics_addr", "", "The address to serve Prometheus client metrics on, disabled when empty")
 )
 
 // High-level function that calls the Reddit API
-func demoFunc(s RedditAPI) (string, error) {
+func demoFunc(ctx context.Context, s redditclient.RedditAPI) (string, error) {
 	// Retrieve the post
-	post, err := s.GetPost(1)
+	post, err := s.GetPost(ctx, 1)
 	if err != nil {
-		log.Fatal(color.RedString("[GetPost] Error: %v", err))
 		return "", err
 	}
-	log.Print(color.BlueString("[GetPost] Received: %v", post))
+	slog.Debug("Received post", slog.Any("post", post))
 
 	// Retrieve most upvoted comments under the post
-	comments, err := s.GetTopComments(post.Id, 10)
+	comments, err := s.GetTopComments(ctx, post.Id, 10)
 	if err != nil {
-		log.Fatal(color.RedString("[GetTopComments] Error: %v", err))
 		return "", err
 	}
-	log.Print(color.BlueString("[GetTopComments] Received: %v", comments))
+	slog.Debug("Received comments", slog.Any("comments", comments))
+	if len(comments) == 0 {
+		return "", nil
+	}
 
 	// Expand the most upvoted comment
-	commentsOfComment, err := s.ExpandCommentBranch(comments[0].Id, 10)
+	commentsOfComment, err := s.ExpandCommentBranch(ctx, comments[0].Id, 10)
 	if err != nil {
-		log.Fatal(color.RedString("[ExpandCommentBranch] Error: %v", err))
 		return "", err
 	}
-	log.Print(color.BlueString("[ExpandCommentBranch] Received: %v", commentsOfComment))
+	slog.Debug("Received comments", slog.Any("comments", commentsOfComment))
 
 	// Return the most upvoted reply under the most upvoted comment
 	if len(commentsOfComment) == 0 {
@@ -47,29 +85,111 @@ func demoFunc(s RedditAPI) (string, error) {
 
 func main() {
 	// Parse command line arguments
+	flag.Usage = usage
 	flag.Parse()
-	s := NewRedditAPIClient(*addr, *port)
 
-	// Run the high-level function demoFunc
-	log.Print(color.BlueString("[Demo] Start!"))
-	result, err := demoFunc(s)
+	// Set up logging, which would draw over the TUI or bury the load
+	// generator report unless written to a file
+	var logOutput io.Writer = os.Stderr
+	if *logFile != "" {
+		f, err := os.OpenFile(*logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			log.Fatalf("Failed to open log file: %v", err)
+		}
+		defer f.Close()
+		logOutput = f
+	} else if flag.Arg(0) == "tui" || flag.Arg(0) == "loadgen" {
+		logOutput = io.Discard
+	}
+	logger, err := newLogger(logOutput, *logFormat, *logLevel)
+	if err != nil {
+		log.Fatalf("Invalid logging flags: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	// Resolve the server profile
+	required := false
+	flag.Visit(func(f *flag.Flag) { required = required || f.Name == "config" })
+	cfg, err := loadConfig(*configFile, required)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	p, err := cfg.profile(*profileName)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if err := p.applyFlags(set); err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+	out, err := newPrinter(os.Stdout, p.Output)
+	if err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+
+	// Set up tracing
+	shutdownTracing, err := setupTracing(context.Background(), *traceExporter, *otlpEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Launch the metrics listener
+	if *metricsAddr != "" {
+		registry := prometheus.NewRegistry()
+		if err := redditclient.RegisterMetrics(registry); err != nil {
+			log.Fatalf("Failed to register metrics: %v", err)
+		}
+		go func() {
+			logger.Info("Serving metrics", slog.String("addr", *metricsAddr))
+			server := &http.Server{
+				Addr:              *metricsAddr,
+				Handler:           promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+				ReadHeaderTimeout: metricsReadHeaderTimeout,
+			}
+			err := server.ListenAndServe()
+			log.Fatalf("Failed to serve metrics: %v", err)
+		}()
+	}
+
+	opts := []redditclient.Option{
+		redditclient.WithAddress(p.Address),
+		redditclient.WithLogger(logger),
+		redditclient.WithLogPayloadLimit(*logPayloadLimit),
+	}
+	if *hedging {
+		opts = append(opts, redditclient.WithHedging(redditclient.DefaultHedgingPolicy))
+	}
+	if p.TLS {
+		creds, err := redditclient.TLSCredentials(p.CAFile, p.CertFile, p.KeyFile, p.ServerHostOverride)
+		if err != nil {
+			log.Fatalf("Failed to load TLS credentials: %v", err)
+		}
+		opts = append(opts, redditclient.WithTransportCredentials(creds))
+	}
+	if p.Token != "" {
+		opts = append(opts, redditclient.WithToken(p.Token))
+	}
+	s, err := redditclient.NewRedditAPIClient(opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer s.Close()
+
+	// Run the command until it finishes or is interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	c := &cli{api: s, out: out, stderr: os.Stderr, timeout: *timeout}
+	err = c.run(ctx, flag.Args())
+	stop()
 	if err != nil {
-		log.Fatal(color.RedString("[Demo] Error: %v", err))
-	}
-	log.Print(color.BlueString("[Demo] Result: %v", result))
-	log.Print(color.BlueString("[Demo] Done!"))
-
-	// Run individual demo functions
-	s.runCreatePost()
-	s.runVotePost()
-	s.runGetPost()
-	s.runCreateComment()
-	s.runVoteComment()
-	s.runGetComment()
-	s.runGetTopComments()
-	s.runExpandCommentBranch()
-	s.runMonitorUpdates()
-
-	// Close the connection
-	s.close()
+		s.Close()
+		log.Fatalf("Error: %v", err)
+	}
+}
+
+func usage() {
+	(&cli{stderr: flag.CommandLine.Output()}).usage()
+	fmt.Fprintf(flag.CommandLine.Output(), "\nFlags:\n")
+	flag.PrintDefaults()
 }
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Writer of command results as a table, JSON or YAML
+type printer struct {
+	w      io.Writer
+	format string
+
+	// Streams print the table header and YAML separator once
+	streaming bool
+	printed   bool
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, want table, json or yaml", format)
+	}
+}
+
+func (p *printer) print(msg proto.Message) error {
+	defer func() { p.printed = true }()
+	switch p.format {
+	case "json":
+		options := protojson.MarshalOptions{Multiline: !p.streaming, Indent: "  "}
+		data, err := options.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", data)
+		return err
+	case "yaml":
+		return p.printYAML(msg)
+	default:
+		return p.printTable(msg)
+	}
+}
+
+// YAML of the JSON mapping of msg, as one document per message of a stream
+func (p *printer) printYAML(msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if p.streaming && p.printed {
+		fmt.Fprintln(p.w, "---")
+	}
+	encoder := yaml.NewEncoder(p.w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func (p *printer) printTable(msg proto.Message) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	header := func(columns ...string) {
+		if !p.streaming || !p.printed {
+			fmt.Fprintln(tw, strings.Join(columns, "\t"))
+		}
+	}
+	footer := ""
+
+	switch m := msg.(type) {
+	case *pb.Post:
+		header(postColumns...)
+		writePost(tw, m)
+	case *pb.ListPostsResponse:
+		header(postColumns...)
+		for _, post := range m.GetPosts() {
+			writePost(tw, post)
+		}
+		if m.GetNextPageToken() != "" {
+			footer = "Next page: -page " + m.GetNextPageToken()
+		}
+	case *pb.SearchPostsResponse:
+		header(postColumns...)
+		for _, post := range m.GetPosts() {
+			writePost(tw, post)
+		}
+	case *pb.Comment:
+		header(commentColumns...)
+		writeComments(tw, []*pb.Comment{m}, 0)
+	case *pb.GetTopCommentsResponse:
+		header(commentColumns...)
+		writeComments(tw, m.GetComments(), 0)
+	case *pb.SubReddit:
+		header(subRedditColumns...)
+		writeSubReddit(tw, m)
+	case *pb.ListSubRedditsResponse:
+		header(subRedditColumns...)
+		for _, subReddit := range m.GetSubReddits() {
+			writeSubReddit(tw, subReddit)
+		}
+		if m.GetNextPageToken() != "" {
+			footer = "Next page: -page " + m.GetNextPageToken()
+		}
+	case *pb.VotePostResponse:
+		header("SCORE")
+		fmt.Fprintf(tw, "%d\n", m.GetScore())
+	case *pb.VoteCommentResponse:
+		header("SCORE")
+		fmt.Fprintf(tw, "%d\n", m.GetScore())
+	case *pb.MonitorUpdatesResponse:
+		header("TYPE", "ID", "SCORE")
+		score := fmt.Sprint(m.GetScore())
+		if m.GetNotFound() {
+			score = "not found"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", m.GetContentType(), m.GetContentID(), score)
+	default:
+		fmt.Fprintln(tw, prototextString(msg))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if footer != "" {
+		_, err := fmt.Fprintln(p.w, footer)
+		return err
+	}
+	return nil
+}
+
+var (
+	postColumns      = []string{"ID", "SCORE", "SUBREDDIT", "AUTHOR", "TITLE"}
+	commentColumns   = []string{"ID", "SCORE", "AUTHOR", "PARENT", "CONTENT"}
+	subRedditColumns = []string{"ID", "NAME", "STATE", "TAGS"}
+)
+
+func writePost(w io.Writer, post *pb.Post) {
+	fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s\n",
+		post.GetId(), post.GetScore(), post.GetSubReddit().GetId(), userString(post.GetAuthor()), post.GetTitle())
+}
+
+// Comments with their children indented under them
+func writeComments(w io.Writer, comments []*pb.Comment, depth int) {
+	for _, comment := range comments {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s %d\t%s%s\n",
+			comment.GetId(), comment.GetScore(), userString(comment.GetAuthor()),
+			strings.ToLower(comment.GetParent().String()), comment.GetParentID(),
+			strings.Repeat("  ", depth), comment.GetContent())
+		writeComments(w, comment.GetChildren(), depth+1)
+	}
+}
+
+func writeSubReddit(w io.Writer, subReddit *pb.SubReddit) {
+	fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+		subReddit.GetId(), subReddit.GetName(), subReddit.GetState(), strings.Join(subReddit.GetTags(), ","))
+}
+
+func userString(user *pb.User) string {
+	if user == nil {
+		return "-"
+	}
+	return fmt.Sprint(user.GetId())
+}
+
+func prototextString(msg proto.Message) string {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
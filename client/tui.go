@@ -0,0 +1,594 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"google.golang.org/grpc/status"
+)
+
+const tuiHelp = "j/k move  enter open/expand  u/d vote  r reply  g refresh  esc back  q quit"
+
+type tuiScreen int
+
+const (
+	screenSubReddits tuiScreen = iota
+	screenPosts
+	screenPost
+)
+
+// Comment of the tree shown under a post, with its replies loaded on demand
+type commentNode struct {
+	comment   *redditclient.RedditComment
+	depth     int
+	children  []*commentNode
+	loaded    bool
+	collapsed bool
+}
+
+// Selectable line of the current screen
+type tuiRow struct {
+	text      string
+	subReddit *redditclient.RedditSubReddit
+	post      *redditclient.RedditPost
+	node      *commentNode
+}
+
+// Interactive browser of subreddits, posts and comment trees. Keys and score
+// updates are handled on a single goroutine, so the state needs no locking.
+type tui struct {
+	api      redditclient.RedditAPI
+	sub      *redditclient.Subscription
+	userID   int32
+	quantity int32
+	timeout  time.Duration
+	resize   time.Duration // How often the terminal size is checked
+
+	screen     tuiScreen
+	subReddits []*redditclient.RedditSubReddit
+	subReddit  *redditclient.RedditSubReddit
+	posts      []*redditclient.RedditPost
+	post       *redditclient.RedditPost
+	comments   []*commentNode
+	watched    map[int32]bool // Comments subscribed to, besides the post
+
+	cursor    int
+	offset    int
+	status    string
+	replying  bool
+	replyText string
+	quit      bool
+}
+
+func newTUI(api redditclient.RedditAPI, userID int32, timeout time.Duration) *tui {
+	return &tui{api: api, userID: userID, quantity: 20, timeout: timeout, resize: 250 * time.Millisecond, watched: map[int32]bool{}}
+}
+
+// Deadline of each call
+func (t *tui) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, t.timeout)
+}
+
+// Show a failed call in the status line instead of leaving the TUI
+func (t *tui) fail(err error) {
+	t.status = "Error: " + status.Convert(err).Message()
+}
+
+/**
+ *
+ * Loading
+ *
+ */
+
+func (t *tui) loadSubReddits(ctx context.Context) {
+	ctx, cancel := t.callContext(ctx)
+	defer cancel()
+	subReddits, _, err := t.api.ListSubReddits(ctx, 100, "")
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.screen, t.subReddits, t.cursor, t.offset = screenSubReddits, subReddits, 0, 0
+}
+
+func (t *tui) loadPosts(ctx context.Context, subReddit *redditclient.RedditSubReddit) {
+	ctx, cancel := t.callContext(ctx)
+	defer cancel()
+	posts, _, err := t.api.ListPosts(ctx, subReddit.GetId(), 100, "")
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.screen, t.subReddit, t.posts, t.cursor, t.offset = screenPosts, subReddit, posts, 0, 0
+}
+
+// Open a post with its top comments and watch their scores
+func (t *tui) loadPost(ctx context.Context, postID int32) {
+	ctx, cancel := t.callContext(ctx)
+	defer cancel()
+	post, err := t.api.GetPost(ctx, postID)
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	comments, err := t.api.GetTopComments(ctx, postID, t.quantity)
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.unwatchPost()
+	t.screen, t.post, t.cursor, t.offset = screenPost, post, 0, 0
+	t.comments = t.newNodes(comments, 0)
+	if t.sub != nil {
+		t.sub.Add(redditclient.ContentPost, post.GetId())
+	}
+}
+
+// Load the replies of a comment the first time it is expanded
+func (t *tui) expand(ctx context.Context, node *commentNode) {
+	if !node.loaded {
+		ctx, cancel := t.callContext(ctx)
+		defer cancel()
+		replies, err := t.api.ExpandCommentBranch(ctx, node.comment.GetId(), t.quantity)
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		node.children = t.newNodes(replies, node.depth+1)
+		node.loaded = true
+	}
+	node.collapsed = false
+}
+
+// Wrap comments in nodes and watch their scores
+func (t *tui) newNodes(comments []*redditclient.RedditComment, depth int) []*commentNode {
+	nodes := make([]*commentNode, len(comments))
+	for i, comment := range comments {
+		nodes[i] = &commentNode{comment: comment, depth: depth}
+		if t.sub != nil && !t.watched[comment.GetId()] {
+			t.sub.Add(redditclient.ContentComment, comment.GetId())
+		}
+		t.watched[comment.GetId()] = true
+	}
+	return nodes
+}
+
+// Stop watching the open post and its comments
+func (t *tui) unwatchPost() {
+	if t.sub != nil {
+		if t.post != nil {
+			t.sub.Remove(redditclient.ContentPost, t.post.GetId())
+		}
+		for id := range t.watched {
+			t.sub.Remove(redditclient.ContentComment, id)
+		}
+	}
+	t.post, t.comments, t.watched = nil, nil, map[int32]bool{}
+}
+
+/**
+ *
+ * Rows
+ *
+ */
+
+func (t *tui) rows() []tuiRow {
+	var rows []tuiRow
+	switch t.screen {
+	case screenSubReddits:
+		for _, subReddit := range t.subReddits {
+			text := subReddit.GetName()
+			if len(subReddit.GetTags()) > 0 {
+				text += "  [" + strings.Join(subReddit.GetTags(), ", ") + "]"
+			}
+			rows = append(rows, tuiRow{text: text, subReddit: subReddit})
+		}
+	case screenPosts:
+		for _, post := range t.posts {
+			rows = append(rows, tuiRow{text: fmt.Sprintf("%5d  %s", post.GetScore(), post.GetTitle()), post: post})
+		}
+	case screenPost:
+		rows = append(rows, tuiRow{text: fmt.Sprintf("%5d  %s", t.post.GetScore(), t.post.GetTitle()), post: t.post})
+		rows = appendCommentRows(rows, t.comments)
+	}
+	return rows
+}
+
+func appendCommentRows(rows []tuiRow, nodes []*commentNode) []tuiRow {
+	for _, node := range nodes {
+		marker := "▾"
+		if !node.loaded || node.collapsed {
+			marker = "▸"
+		} else if len(node.children) == 0 {
+			marker = "·"
+		}
+		text := fmt.Sprintf("%s%s %d  %s  (u/%s)",
+			strings.Repeat("  ", node.depth+1), marker, node.comment.GetScore(), node.comment.GetContent(), userString(node.comment.GetAuthor()))
+		rows = append(rows, tuiRow{text: text, node: node})
+		if node.loaded && !node.collapsed {
+			rows = appendCommentRows(rows, node.children)
+		}
+	}
+	return rows
+}
+
+/**
+ *
+ * Events
+ *
+ */
+
+// Handle a key of the parsed terminal input
+func (t *tui) handleKey(ctx context.Context, k string) {
+	if t.replying {
+		t.handleReplyKey(ctx, k)
+		return
+	}
+	rows := t.rows()
+	var row tuiRow
+	if t.cursor < len(rows) {
+		row = rows[t.cursor]
+	}
+	t.status = ""
+
+	switch k {
+	case "q", "ctrl+c":
+		t.quit = true
+	case "j", "down":
+		if t.cursor < len(rows)-1 {
+			t.cursor++
+		}
+	case "k", "up":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "enter", " ", "l", "right":
+		switch {
+		case row.subReddit != nil:
+			t.loadPosts(ctx, row.subReddit)
+		case row.post != nil && t.screen == screenPosts:
+			t.loadPost(ctx, row.post.GetId())
+		case row.node != nil && (!row.node.loaded || row.node.collapsed):
+			t.expand(ctx, row.node)
+		case row.node != nil:
+			row.node.collapsed = true
+		}
+	case "h", "left":
+		if row.node != nil && row.node.loaded && !row.node.collapsed {
+			row.node.collapsed = true
+			break
+		}
+		t.back(ctx)
+	case "esc", "backspace":
+		t.back(ctx)
+	case "u", "d":
+		t.vote(ctx, row, k == "u")
+	case "r":
+		if (row.post != nil && t.screen == screenPost) || row.node != nil {
+			t.replying, t.replyText = true, ""
+		}
+	case "g":
+		t.refresh(ctx)
+	}
+}
+
+func (t *tui) back(ctx context.Context) {
+	switch t.screen {
+	case screenPost:
+		t.unwatchPost()
+		t.screen, t.cursor, t.offset = screenPosts, 0, 0
+	case screenPosts:
+		t.screen, t.subReddit, t.posts, t.cursor, t.offset = screenSubReddits, nil, nil, 0, 0
+	}
+}
+
+func (t *tui) refresh(ctx context.Context) {
+	cursor := t.cursor
+	switch t.screen {
+	case screenSubReddits:
+		t.loadSubReddits(ctx)
+	case screenPosts:
+		t.loadPosts(ctx, t.subReddit)
+	case screenPost:
+		t.loadPost(ctx, t.post.GetId())
+	}
+	if rows := t.rows(); cursor < len(rows) {
+		t.cursor = cursor
+	}
+}
+
+func (t *tui) vote(ctx context.Context, row tuiRow, upvote bool) {
+	ctx, cancel := t.callContext(ctx)
+	defer cancel()
+	switch {
+	case row.post != nil:
+		score, err := t.api.VotePost(ctx, row.post.GetId(), upvote, redditclient.WithVoter(t.userID))
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		row.post.Score = score
+	case row.node != nil:
+		score, err := t.api.VoteComment(ctx, row.node.comment.GetId(), upvote, redditclient.WithVoter(t.userID))
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		row.node.comment.Score = score
+	}
+}
+
+func (t *tui) handleReplyKey(ctx context.Context, k string) {
+	switch k {
+	case "esc", "ctrl+c":
+		t.replying = false
+	case "backspace":
+		_, size := utf8.DecodeLastRuneInString(t.replyText)
+		t.replyText = t.replyText[:len(t.replyText)-size]
+	case "enter":
+		t.replying = false
+		if strings.TrimSpace(t.replyText) != "" {
+			t.reply(ctx, t.rows()[t.cursor], t.replyText)
+		}
+	default:
+		if utf8.RuneCountInString(k) == 1 {
+			t.replyText += k
+		}
+	}
+}
+
+// Reply to the post or comment of the row, and show the reply under it
+func (t *tui) reply(ctx context.Context, row tuiRow, content string) {
+	ctx, cancel := t.callContext(ctx)
+	defer cancel()
+	if row.node == nil {
+		comment, err := t.api.ReplyToPost(ctx, t.post.GetId(), t.userID, content)
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		t.comments = append(t.newNodes([]*redditclient.RedditComment{comment}, 0), t.comments...)
+		t.status = "Replied to the post"
+		return
+	}
+	comment, err := t.api.ReplyToComment(ctx, row.node.comment.GetId(), t.userID, content)
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.expand(ctx, row.node)
+	if !t.watched[comment.GetId()] {
+		row.node.children = append(row.node.children, t.newNodes([]*redditclient.RedditComment{comment}, row.node.depth+1)...)
+	}
+	t.status = "Replied to the comment"
+}
+
+// Apply a score update of the subscription, reporting whether it changed
+// anything shown
+func (t *tui) handleEvent(event redditclient.Event) bool {
+	if event.Resumed {
+		t.status = "Reconnected, press g to reload missed updates"
+		return true
+	}
+	update := event.Update
+	if update.GetNotFound() {
+		// Deleted since it was loaded, and no longer watched
+		return false
+	}
+	changed := false
+	setScore := func(score *int32) {
+		if *score != update.GetScore() {
+			*score, changed = update.GetScore(), true
+		}
+	}
+	switch update.GetContentType() {
+	case redditclient.ContentPost:
+		if t.post != nil && t.post.GetId() == update.GetContentID() {
+			setScore(&t.post.Score)
+		}
+		for _, post := range t.posts {
+			if post.GetId() == update.GetContentID() {
+				setScore(&post.Score)
+			}
+		}
+	case redditclient.ContentComment:
+		walkComments(t.comments, func(node *commentNode) {
+			if node.comment.GetId() == update.GetContentID() {
+				setScore(&node.comment.Score)
+			}
+		})
+	}
+	return changed
+}
+
+// Visit every loaded comment, collapsed or not
+func walkComments(nodes []*commentNode, visit func(*commentNode)) {
+	for _, node := range nodes {
+		visit(node)
+		walkComments(node.children, visit)
+	}
+}
+
+/**
+ *
+ * Rendering
+ *
+ */
+
+// Draw the screen in a width by height terminal
+func (t *tui) render(w io.Writer, width, height int) error {
+	var b bytes.Buffer
+	lines := []string{}
+	switch t.screen {
+	case screenSubReddits:
+		lines = append(lines, "Subreddits")
+	case screenPosts:
+		lines = append(lines, t.subReddit.GetName())
+	case screenPost:
+		lines = append(lines, fmt.Sprintf("%s  by u/%s", t.subReddit.GetName(), userString(t.post.GetAuthor())))
+		for _, line := range strings.Split(t.post.GetContent(), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	lines = append(lines, "")
+
+	// Scroll the rows to keep the cursor in view
+	rows := t.rows()
+	visible := height - len(lines) - 2
+	if visible < 1 {
+		visible = 1
+	}
+	if t.cursor >= len(rows) {
+		t.cursor = max(len(rows)-1, 0)
+	}
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+visible {
+		t.offset = t.cursor - visible + 1
+	}
+
+	b.WriteString("\x1b[H\x1b[2J")
+	for _, line := range lines {
+		b.WriteString("\x1b[1m" + truncate(line, width) + "\x1b[0m\r\n")
+	}
+	for i := t.offset; i < len(rows) && i < t.offset+visible; i++ {
+		text := truncate(rows[i].text, width)
+		if i == t.cursor {
+			text = "\x1b[7m" + text + "\x1b[0m"
+		}
+		b.WriteString(text + "\r\n")
+	}
+
+	// Status and help on the bottom lines
+	bottom := tuiHelp
+	if t.replying {
+		bottom = "Reply: " + t.replyText + "█"
+	}
+	fmt.Fprintf(&b, "\x1b[%d;1H\x1b[2m%s\x1b[0m\r\n%s", height-1, truncate(t.status, width), truncate(bottom, width))
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Cut s to width runes
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 1 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Split terminal input into keys, reading arrows from their escape sequences
+func parseKeys(input []byte) []string {
+	var keys []string
+	for len(input) > 0 {
+		switch {
+		case bytes.HasPrefix(input, []byte("\x1b[")) && len(input) >= 3:
+			switch input[2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			}
+			input = input[3:]
+			continue
+		case input[0] == 0x1b:
+			keys = append(keys, "esc")
+		case input[0] == '\r' || input[0] == '\n':
+			keys = append(keys, "enter")
+		case input[0] == 0x7f || input[0] == 0x08:
+			keys = append(keys, "backspace")
+		case input[0] == 0x03:
+			keys = append(keys, "ctrl+c")
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r != utf8.RuneError && r >= ' ' {
+				keys = append(keys, string(r))
+			}
+			input = input[size:]
+			continue
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+// Run the TUI until quit, reading keys from in and drawing to out at the
+// size size reports
+func (t *tui) run(ctx context.Context, in io.Reader, out io.Writer, size func() (int, int)) error {
+	t.sub = t.api.Subscribe(ctx)
+	defer t.sub.Close()
+
+	keys := make(chan []byte)
+	go func() {
+		defer close(keys)
+		for {
+			buf := make([]byte, 64)
+			n, err := in.Read(buf)
+			if n > 0 {
+				select {
+				case keys <- buf[:n]:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Redraw when the terminal is resized
+	resize := time.NewTicker(t.resize)
+	defer resize.Stop()
+
+	t.loadSubReddits(ctx)
+	width, height := size()
+	redraw := true
+	for !t.quit {
+		if redraw {
+			if err := t.render(out, width, height); err != nil {
+				return err
+			}
+		}
+		select {
+		case input, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, k := range parseKeys(input) {
+				t.handleKey(ctx, k)
+			}
+			redraw = true
+		case event, ok := <-t.sub.Updates():
+			if !ok {
+				return t.sub.Err()
+			}
+			redraw = t.handleEvent(event)
+		case <-resize.C:
+			w, h := size()
+			redraw = w != width || h != height
+			width, height = w, h
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
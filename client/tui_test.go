@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomy0000000/grpc-reddit/reddit/fakereddit"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+)
+
+func TestTUIBrowse(t *testing.T) {
+	s := fakereddit.New(fakereddit.DefaultFixtures())
+	ctx := context.Background()
+	ui := newTUI(fakereddit.NewClient(t, s), 1, 0)
+	ui.loadSubReddits(ctx)
+	for _, k := range []string{"enter", "enter", "j", "enter", "u"} {
+		ui.handleKey(ctx, k)
+	}
+	require.Empty(t, ui.status)
+	assert.Equal(t, screenPost, ui.screen)
+	assert.Equal(t, []fakereddit.Vote{{ContentType: pb.ContentType_COMMENT, ContentID: 1, VoterID: 1, Upvote: true}}, s.Votes())
+
+	var out bytes.Buffer
+	require.NoError(t, ui.render(&out, 80, 24))
+	assert.Contains(t, out.String(), "r/aww  by u/1")
+	assert.Contains(t, out.String(), "  Interdum efficitur massa")
+	assert.Contains(t, out.String(), "\x1b[7m  ▾ 3  Fermentum aliquet adipiscing  (u/2)\x1b[0m")
+	assert.Contains(t, out.String(), "    ▸ 0  Massa a litora  (u/1)")
+
+	// Reply to the selected comment
+	for _, k := range []string{"r", "H", "i", "!", "backspace", "enter"} {
+		ui.handleKey(ctx, k)
+	}
+	require.Len(t, s.CreatedComments(), 1)
+	assert.Equal(t, "Hi", s.CreatedComments()[0].GetContent())
+	assert.Equal(t, int32(1), s.CreatedComments()[0].GetParentID())
+	assert.Len(t, ui.rows(), 4)
+
+	// Live updates change the scores of collapsed comments too
+	ui.handleKey(ctx, "h")
+	update := redditclient.Event{Update: &redditclient.Update{ContentType: pb.ContentType_COMMENT, ContentID: 2, Score: 7}}
+	assert.True(t, ui.handleEvent(update))
+	assert.False(t, ui.handleEvent(update), "unchanged scores need no redraw")
+	assert.Len(t, ui.rows(), 2)
+	assert.Equal(t, int32(7), ui.comments[0].children[0].comment.GetScore())
+
+	// Back to the posts, and quit
+	ui.handleKey(ctx, "esc")
+	assert.Equal(t, screenPosts, ui.screen)
+	ui.handleKey(ctx, "q")
+	assert.True(t, ui.quit)
+}
+
+// Terminal output counting the screens rendered
+type screenWriter struct {
+	mu      sync.Mutex
+	renders int
+}
+
+func (w *screenWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.renders += strings.Count(string(p), "\x1b[2J")
+	return len(p), nil
+}
+
+func (w *screenWriter) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.renders
+}
+
+func TestTUIRedraw(t *testing.T) {
+	ui := newTUI(fakereddit.NewClient(t, fakereddit.New(fakereddit.DefaultFixtures())), 1, 0)
+	ui.resize = time.Millisecond
+	in, keys := io.Pipe()
+	defer keys.Close()
+	out := &screenWriter{}
+	var checks, width atomic.Int32
+	width.Store(80)
+	size := func() (int, int) {
+		checks.Add(1)
+		return int(width.Load()), 24
+	}
+	done := make(chan error, 1)
+	go func() { done <- ui.run(context.Background(), in, out, size) }()
+
+	// Ticks with an unchanged size render nothing
+	require.Eventually(t, func() bool { return checks.Load() > 10 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, 1, out.count())
+
+	width.Store(100)
+	require.Eventually(t, func() bool { return out.count() == 2 }, 5*time.Second, time.Millisecond)
+	_, err := keys.Write([]byte("j"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return out.count() == 3 }, 5*time.Second, time.Millisecond)
+
+	_, err = keys.Write([]byte("q"))
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+	assert.Equal(t, 3, out.count())
+}
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []string{"up", "down", "enter", "j", "é", "backspace", "esc", "ctrl+c"},
+		parseKeys([]byte("\x1b[A\x1b[B\rjé\x7f\x1b\x03")))
+}
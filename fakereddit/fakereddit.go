@@ -0,0 +1,608 @@
+// Package fakereddit is an in-memory fake of the Reddit gRPC service for
+// tests of its clients. Its state is deterministic and seeded from fixtures,
+// calls can be made to fail or slow down, and helpers inspect what was
+// created and voted.
+package fakereddit
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxBatchSize    = 100
+)
+
+// Vote received by VotePost or VoteComment
+type Vote struct {
+	ContentType pb.ContentType
+	ContentID   int32
+	VoterID     int32
+	Upvote      bool
+}
+
+// Server is a fake pb.RedditServer. Unlike the real server, MonitorUpdates
+// and WatchUpdates send a score as soon as it is subscribed to or changes
+// instead of polling, so tests need not wait.
+type Server struct {
+	pb.UnimplementedRedditServer
+
+	mu              sync.Mutex
+	subReddits      map[int32]*pb.SubReddit
+	posts           map[int32]*pb.Post
+	comments        map[int32]*pb.Comment
+	nextPostID      int32
+	nextCommentID   int32
+	createdPosts    []int32
+	createdComments []int32
+	votes           []Vote
+	watchers        map[*watcher]bool
+
+	calls   map[string]int
+	faults  map[string][]fault
+	latency map[string]time.Duration
+}
+
+var _ pb.RedditServer = (*Server)(nil)
+
+// New creates a server holding copies of the fixtures. New posts and
+// comments get the IDs following the highest ones of the fixtures.
+func New(fixtures Fixtures) *Server {
+	s := &Server{
+		subReddits:    map[int32]*pb.SubReddit{},
+		posts:         map[int32]*pb.Post{},
+		comments:      map[int32]*pb.Comment{},
+		nextPostID:    1,
+		nextCommentID: 1,
+		watchers:      map[*watcher]bool{},
+		calls:         map[string]int{},
+		faults:        map[string][]fault{},
+		latency:       map[string]time.Duration{},
+	}
+	for _, subReddit := range fixtures.SubReddits {
+		s.subReddits[subReddit.GetId()] = clone(subReddit)
+	}
+	for _, post := range fixtures.Posts {
+		s.posts[post.GetId()] = clone(post)
+		s.nextPostID = max(s.nextPostID, post.GetId()+1)
+	}
+	for _, comment := range fixtures.Comments {
+		s.comments[comment.GetId()] = clone(comment)
+		s.nextCommentID = max(s.nextCommentID, comment.GetId()+1)
+	}
+	return s
+}
+
+func clone[M proto.Message](m M) M {
+	return proto.Clone(m).(M)
+}
+
+var errNotFound = status.Error(codes.NotFound, "not found")
+
+/**
+ *
+ * Inspection
+ *
+ */
+
+// Post returns a copy of the post, or nil if there is none with the ID
+func (s *Server) Post(id int32) *pb.Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if post, ok := s.posts[id]; ok {
+		return clone(post)
+	}
+	return nil
+}
+
+// Comment returns a copy of the comment, or nil if there is none with the ID
+func (s *Server) Comment(id int32) *pb.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if comment, ok := s.comments[id]; ok {
+		return clone(comment)
+	}
+	return nil
+}
+
+// Posts returns copies of every post, by ID
+func (s *Server) Posts() []*pb.Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedCopies(s.posts)
+}
+
+// Comments returns copies of every comment, by ID
+func (s *Server) Comments() []*pb.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedCopies(s.comments)
+}
+
+// CreatedPosts returns copies of the posts created through CreatePost, in
+// the order they were created
+func (s *Server) CreatedPosts() []*pb.Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	posts := make([]*pb.Post, len(s.createdPosts))
+	for i, id := range s.createdPosts {
+		posts[i] = clone(s.posts[id])
+	}
+	return posts
+}
+
+// CreatedComments returns copies of the comments created through
+// CreateComment, in the order they were created
+func (s *Server) CreatedComments() []*pb.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comments := make([]*pb.Comment, len(s.createdComments))
+	for i, id := range s.createdComments {
+		comments[i] = clone(s.comments[id])
+	}
+	return comments
+}
+
+// Votes returns the votes received, in order
+func (s *Server) Votes() []Vote {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Vote{}, s.votes...)
+}
+
+func sortedCopies[M interface {
+	proto.Message
+	GetId() int32
+}](contents map[int32]M) []M {
+	copies := make([]M, 0, len(contents))
+	for _, content := range contents {
+		copies = append(copies, clone(content))
+	}
+	sort.Slice(copies, func(i, j int) bool { return copies[i].GetId() < copies[j].GetId() })
+	return copies
+}
+
+/**
+ *
+ * Posts and comments
+ *
+ */
+
+func (s *Server) CreatePost(ctx context.Context, in *pb.CreatePostRequest) (*pb.CreatePostResponse, error) {
+	if err := s.enter(ctx, "CreatePost"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post := &pb.Post{}
+	if in.GetPost() != nil {
+		post = clone(in.GetPost())
+	}
+	post.Id = s.nextPostID
+	s.nextPostID++
+	s.posts[post.Id] = post
+	s.createdPosts = append(s.createdPosts, post.Id)
+	return &pb.CreatePostResponse{Post: clone(post)}, nil
+}
+
+func (s *Server) VotePost(ctx context.Context, in *pb.VotePostRequest) (*pb.VotePostResponse, error) {
+	if err := s.enter(ctx, "VotePost"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post, ok := s.posts[in.GetPostID()]
+	if !ok {
+		return nil, errNotFound
+	}
+	post.Score += voteDelta(in.GetUpvote())
+	s.votes = append(s.votes, Vote{pb.ContentType_POST, in.GetPostID(), in.GetVoterID(), in.GetUpvote()})
+	s.publish(pb.ContentType_POST, post.Id, post.Score)
+	return &pb.VotePostResponse{Score: post.Score}, nil
+}
+
+func (s *Server) GetPost(ctx context.Context, in *pb.GetPostRequest) (*pb.GetPostResponse, error) {
+	if err := s.enter(ctx, "GetPost"); err != nil {
+		return nil, err
+	}
+	post := s.Post(in.GetPostID())
+	if post == nil {
+		return nil, errNotFound
+	}
+	return &pb.GetPostResponse{Post: post}, nil
+}
+
+func (s *Server) CreateComment(ctx context.Context, in *pb.CreateCommentRequest) (*pb.CreateCommentResponse, error) {
+	if err := s.enter(ctx, "CreateComment"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comment := &pb.Comment{}
+	if in.GetComment() != nil {
+		comment = clone(in.GetComment())
+	}
+	comment.Id = s.nextCommentID
+	comment.Children = nil
+	s.nextCommentID++
+	s.comments[comment.Id] = comment
+	s.createdComments = append(s.createdComments, comment.Id)
+	return &pb.CreateCommentResponse{Comment: clone(comment)}, nil
+}
+
+func (s *Server) VoteComment(ctx context.Context, in *pb.VoteCommentRequest) (*pb.VoteCommentResponse, error) {
+	if err := s.enter(ctx, "VoteComment"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comment, ok := s.comments[in.GetCommentID()]
+	if !ok {
+		return nil, errNotFound
+	}
+	comment.Score += voteDelta(in.GetUpvote())
+	s.votes = append(s.votes, Vote{pb.ContentType_COMMENT, in.GetCommentID(), in.GetVoterID(), in.GetUpvote()})
+	s.publish(pb.ContentType_COMMENT, comment.Id, comment.Score)
+	return &pb.VoteCommentResponse{Score: comment.Score}, nil
+}
+
+func voteDelta(upvote bool) int32 {
+	if upvote {
+		return 1
+	}
+	return -1
+}
+
+func (s *Server) GetComment(ctx context.Context, in *pb.GetCommentRequest) (*pb.GetCommentResponse, error) {
+	if err := s.enter(ctx, "GetComment"); err != nil {
+		return nil, err
+	}
+	comment := s.Comment(in.GetCommentID())
+	if comment == nil {
+		return nil, errNotFound
+	}
+	return &pb.GetCommentResponse{Comment: comment}, nil
+}
+
+func (s *Server) BatchGetPosts(ctx context.Context, in *pb.BatchGetPostsRequest) (*pb.BatchGetPostsResponse, error) {
+	if err := s.enter(ctx, "BatchGetPosts"); err != nil {
+		return nil, err
+	}
+	if len(in.GetPostIDs()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d IDs can be requested at once, got %d", maxBatchSize, len(in.GetPostIDs()))
+	}
+	results := make([]*pb.PostResult, len(in.GetPostIDs()))
+	for i, id := range in.GetPostIDs() {
+		post := s.Post(id)
+		results[i] = &pb.PostResult{PostID: id, Post: post, NotFound: post == nil}
+	}
+	return &pb.BatchGetPostsResponse{Results: results}, nil
+}
+
+func (s *Server) BatchGetComments(ctx context.Context, in *pb.BatchGetCommentsRequest) (*pb.BatchGetCommentsResponse, error) {
+	if err := s.enter(ctx, "BatchGetComments"); err != nil {
+		return nil, err
+	}
+	if len(in.GetCommentIDs()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d IDs can be requested at once, got %d", maxBatchSize, len(in.GetCommentIDs()))
+	}
+	results := make([]*pb.CommentResult, len(in.GetCommentIDs()))
+	for i, id := range in.GetCommentIDs() {
+		comment := s.Comment(id)
+		results[i] = &pb.CommentResult{CommentID: id, Comment: comment, NotFound: comment == nil}
+	}
+	return &pb.BatchGetCommentsResponse{Results: results}, nil
+}
+
+func (s *Server) GetTopComments(ctx context.Context, in *pb.GetTopCommentsRequest) (*pb.GetTopCommentsResponse, error) {
+	if err := s.enter(ctx, "GetTopComments"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comments := s.replies(pb.ContentType_POST, in.GetPostID(), in.GetQuantity())
+	return &pb.GetTopCommentsResponse{Comments: comments}, nil
+}
+
+// Replies of the comment, each with its own replies, like the real server
+func (s *Server) ExpandCommentBranch(ctx context.Context, in *pb.ExpandCommentBranchRequest) (*pb.ExpandCommentBranchResponse, error) {
+	if err := s.enter(ctx, "ExpandCommentBranch"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comments := s.replies(pb.ContentType_COMMENT, in.GetCommentID(), in.GetQuantity())
+	for _, comment := range comments {
+		comment.Children = s.replies(pb.ContentType_COMMENT, comment.GetId(), in.GetQuantity())
+	}
+	return &pb.ExpandCommentBranchResponse{Comments: comments}, nil
+}
+
+// Copies of the most upvoted comments under the parent
+func (s *Server) replies(parent pb.ContentType, parentID int32, quantity int32) []*pb.Comment {
+	comments := []*pb.Comment{}
+	for _, comment := range sortedCopies(s.comments) {
+		if comment.GetParent() == parent && comment.GetParentID() == parentID {
+			comments = append(comments, comment)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].GetScore() > comments[j].GetScore() })
+	if int(quantity) < len(comments) {
+		comments = comments[:max(quantity, 0)]
+	}
+	return comments
+}
+
+/**
+ *
+ * Listing and search
+ *
+ */
+
+func (s *Server) ListPosts(ctx context.Context, in *pb.ListPostsRequest) (*pb.ListPostsResponse, error) {
+	if err := s.enter(ctx, "ListPosts"); err != nil {
+		return nil, err
+	}
+	afterID, err := parsePageToken(in.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	posts := []*pb.Post{}
+	for _, post := range sortedCopies(s.posts) {
+		if post.GetId() > afterID && visible(post, in.GetSubRedditID()) {
+			posts = append(posts, post)
+		}
+	}
+	response := &pb.ListPostsResponse{Posts: posts}
+	if size := pageSize(in.GetPageSize()); len(posts) > size {
+		response.Posts = posts[:size]
+		response.NextPageToken = strconv.Itoa(int(posts[size-1].GetId()))
+	}
+	return response, nil
+}
+
+func (s *Server) SearchPosts(ctx context.Context, in *pb.SearchPostsRequest) (*pb.SearchPostsResponse, error) {
+	if err := s.enter(ctx, "SearchPosts"); err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(in.GetQuery())
+	if strings.TrimSpace(query) == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty search query")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	posts := []*pb.Post{}
+	for _, post := range sortedCopies(s.posts) {
+		matches := strings.Contains(strings.ToLower(post.GetTitle()), query) || strings.Contains(strings.ToLower(post.GetContent()), query)
+		if matches && visible(post, in.GetSubRedditID()) {
+			posts = append(posts, post)
+		}
+	}
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].GetScore() > posts[j].GetScore() })
+	if size := pageSize(in.GetLimit()); len(posts) > size {
+		posts = posts[:size]
+	}
+	return &pb.SearchPostsResponse{Posts: posts}, nil
+}
+
+// Whether a list of the subreddit, 0 for every one, shows the post
+func visible(post *pb.Post, subRedditID int32) bool {
+	return post.GetState() != pb.PostState_HIDDEN_POST && (subRedditID == 0 || post.GetSubReddit().GetId() == subRedditID)
+}
+
+func (s *Server) GetSubReddit(ctx context.Context, in *pb.GetSubRedditRequest) (*pb.GetSubRedditResponse, error) {
+	if err := s.enter(ctx, "GetSubReddit"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subReddit, ok := s.subReddits[in.GetSubRedditID()]
+	if !ok {
+		return nil, errNotFound
+	}
+	return &pb.GetSubRedditResponse{SubReddit: clone(subReddit)}, nil
+}
+
+func (s *Server) ListSubReddits(ctx context.Context, in *pb.ListSubRedditsRequest) (*pb.ListSubRedditsResponse, error) {
+	if err := s.enter(ctx, "ListSubReddits"); err != nil {
+		return nil, err
+	}
+	afterID, err := parsePageToken(in.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subReddits := []*pb.SubReddit{}
+	for _, subReddit := range sortedCopies(s.subReddits) {
+		if subReddit.GetId() > afterID && subReddit.GetState() != pb.SubRedditState_HIDDEN {
+			subReddits = append(subReddits, subReddit)
+		}
+	}
+	response := &pb.ListSubRedditsResponse{SubReddits: subReddits}
+	if size := pageSize(in.GetPageSize()); len(subReddits) > size {
+		response.SubReddits = subReddits[:size]
+		response.NextPageToken = strconv.Itoa(int(subReddits[size-1].GetId()))
+	}
+	return response, nil
+}
+
+// Page size of a list request, as the real server picks it
+func pageSize(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+func parsePageToken(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(token, 10, 32)
+	if err != nil || id < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+	return int32(id), nil
+}
+
+/**
+ *
+ * Streams
+ *
+ */
+
+type contentKey struct {
+	contentType pb.ContentType
+	id          int32
+}
+
+// Stream of score updates, queued under the server's lock and sent by the
+// stream's own goroutine
+type watcher struct {
+	contents map[contentKey]bool
+	queue    []*pb.MonitorUpdatesResponse
+	notify   chan struct{}
+}
+
+func (s *Server) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error {
+	ctx := stream.Context()
+	if err := s.enter(ctx, "MonitorUpdates"); err != nil {
+		return err
+	}
+	w := s.watch()
+	defer s.unwatch(w)
+
+	// Keep sending after the client closes its side, like the real server
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			s.subscribe(w, in)
+		}
+	}()
+	return s.forward(ctx, w, stream.Send)
+}
+
+func (s *Server) WatchUpdates(in *pb.WatchUpdatesRequest, stream pb.Reddit_WatchUpdatesServer) error {
+	ctx := stream.Context()
+	if err := s.enter(ctx, "WatchUpdates"); err != nil {
+		return err
+	}
+	w := s.watch()
+	defer s.unwatch(w)
+	for _, subscription := range in.GetSubscriptions() {
+		s.subscribe(w, subscription)
+	}
+	return s.forward(ctx, w, stream.Send)
+}
+
+func (s *Server) watch() *watcher {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := &watcher{contents: map[contentKey]bool{}, notify: make(chan struct{}, 1)}
+	s.watchers[w] = true
+	return w
+}
+
+func (s *Server) unwatch(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers, w)
+}
+
+// Subscribe to or unsubscribe from a content, sending its current score
+// right away
+func (s *Server) subscribe(w *watcher, in *pb.MonitorUpdatesRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := contentKey{in.GetContentType(), in.GetContentID()}
+	if in.GetUnsubscribe() {
+		delete(w.contents, key)
+		return
+	}
+
+	var score int32
+	found := false
+	switch key.contentType {
+	case pb.ContentType_POST:
+		var post *pb.Post
+		if post, found = s.posts[key.id]; found {
+			score = post.GetScore()
+		}
+	case pb.ContentType_COMMENT:
+		var comment *pb.Comment
+		if comment, found = s.comments[key.id]; found {
+			score = comment.GetScore()
+		}
+	default:
+		return
+	}
+	// Missing content is reported once and not monitored, like the real server
+	if !found {
+		w.enqueue(&pb.MonitorUpdatesResponse{ContentType: key.contentType, ContentID: key.id, NotFound: true})
+		return
+	}
+	w.contents[key] = true
+	w.enqueue(&pb.MonitorUpdatesResponse{ContentType: key.contentType, ContentID: key.id, Score: score})
+}
+
+// Queue a changed score for every stream subscribed to it, with s.mu held
+func (s *Server) publish(contentType pb.ContentType, id int32, score int32) {
+	for w := range s.watchers {
+		if w.contents[contentKey{contentType, id}] {
+			w.enqueue(&pb.MonitorUpdatesResponse{ContentType: contentType, ContentID: id, Score: score})
+		}
+	}
+}
+
+func (w *watcher) enqueue(update *pb.MonitorUpdatesResponse) {
+	w.queue = append(w.queue, update)
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Send queued updates until ctx is done or sending fails
+func (s *Server) forward(ctx context.Context, w *watcher, send func(*pb.MonitorUpdatesResponse) error) error {
+	for {
+		s.mu.Lock()
+		queue := w.queue
+		w.queue = nil
+		s.mu.Unlock()
+		for _, update := range queue {
+			if err := send(update); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package fakereddit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServerState(t *testing.T) {
+	s := New(DefaultFixtures())
+	client := NewClient(t, s)
+	ctx := context.Background()
+
+	post, err := client.CreatePost(ctx, "Dog Video", "Woof", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), post.GetId())
+	reply, err := client.ReplyToPost(ctx, post.GetId(), 1, "Good dog")
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), reply.GetId())
+	score, err := client.VoteComment(ctx, reply.GetId(), false, redditclient.WithVoter(2))
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), score)
+
+	require.Len(t, s.CreatedPosts(), 1)
+	assert.Equal(t, "Dog Video", s.CreatedPosts()[0].GetTitle())
+	require.Len(t, s.CreatedComments(), 1)
+	assert.Equal(t, post.GetId(), s.CreatedComments()[0].GetParentID())
+	assert.Equal(t, []Vote{{ContentType: pb.ContentType_COMMENT, ContentID: 7, VoterID: 2}}, s.Votes())
+	assert.Len(t, s.Posts(), 3)
+
+	// Replies like the real server, with the replies of each reply
+	comments, err := client.ExpandCommentBranch(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Massa a litora", comments[0].GetContent())
+	assert.Len(t, comments[0].GetChildren(), 1)
+
+	posts, next, err := client.ListPosts(ctx, 0, 2, "")
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, "2", next)
+	posts, err = client.SearchPosts(ctx, "CAT", 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	_, err = client.GetPost(ctx, 100)
+	assert.ErrorIs(t, err, redditclient.ErrNotFound)
+	results, err := client.BatchGetComments(ctx, []int32{7, 100})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Good dog", results[0].GetComment().GetContent())
+	assert.True(t, results[1].GetNotFound())
+}
+
+func TestServerFaults(t *testing.T) {
+	s := New(DefaultFixtures())
+	client := NewClient(t, s, redditclient.WithRetry(redditclient.RetryPolicy{}))
+	ctx := context.Background()
+
+	s.FailNth("GetPost", 2, status.Error(codes.Unavailable, "injected"))
+	_, err := client.GetPost(ctx, 1)
+	require.NoError(t, err)
+	_, err = client.GetPost(ctx, 1)
+	assert.ErrorIs(t, err, redditclient.ErrUnavailable)
+	_, err = client.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Calls("GetPost"))
+
+	s.SetLatency("GetComment", time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetComment(ctx, 1)
+	assert.ErrorIs(t, err, redditclient.ErrDeadlineExceeded)
+
+	s.ClearFaults()
+	_, err = client.GetComment(context.Background(), 1)
+	assert.NoError(t, err)
+}
+
+func TestServerUpdates(t *testing.T) {
+	s := New(DefaultFixtures())
+	client := NewClient(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.MonitorUpdates(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&redditclient.UpdateRequest{ContentType: redditclient.ContentPost, ContentID: 1}))
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), update.GetScore())
+
+	// Votes are sent without polling
+	_, err = client.VotePost(ctx, 1, true)
+	require.NoError(t, err)
+	update, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), update.GetScore())
+}
+
+func TestLoadFixtures(t *testing.T) {
+	f, err := LoadFixtures(strings.NewReader(`{
+		"subReddits": [{"id": 1, "name": "r/golang", "state": "PUBLIC"}],
+		"posts": [{"id": 10, "title": "Generics", "subReddit": {"id": 1}, "score": 5}],
+		"comments": [{"id": 20, "content": "Finally", "parent": "POST", "parentID": 10}]
+	}`))
+	require.NoError(t, err)
+	s := New(f)
+	assert.Equal(t, "Generics", s.Post(10).GetTitle())
+	assert.Equal(t, int32(10), s.Comment(20).GetParentID())
+
+	client := NewClient(t, s)
+	post, err := client.CreatePost(context.Background(), "Iterators", "", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(11), post.GetId())
+
+	_, err = LoadFixtures(strings.NewReader(`{"posts": [{"score": "high"}]}`))
+	assert.ErrorContains(t, err, "decoding post fixtures")
+}
@@ -0,0 +1,75 @@
+package fakereddit
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// Error returned by the nth call of a method
+type fault struct {
+	nth int
+	err error
+}
+
+// FailNth makes the nth call of method, counting from 1 since the server
+// was created, fail with err. Methods are RPC names without the service,
+// such as "GetPost". An err without a gRPC status reaches clients as Unknown.
+func (s *Server) FailNth(method string, n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = append(s.faults[method], fault{nth: n, err: err})
+}
+
+// SetLatency delays every later call of method by d, or until the call is
+// done. A d of 0 removes the delay.
+func (s *Server) SetLatency(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d <= 0 {
+		delete(s.latency, method)
+		return
+	}
+	s.latency[method] = d
+}
+
+// ClearFaults removes every injected error and latency
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = map[string][]fault{}
+	s.latency = map[string]time.Duration{}
+}
+
+// Count a call of method and apply its injected latency and error
+func (s *Server) enter(ctx context.Context, method string) error {
+	s.mu.Lock()
+	s.calls[method]++
+	n := s.calls[method]
+	delay := s.latency[method]
+	var err error
+	for _, f := range s.faults[method] {
+		if f.nth == n {
+			err = f.err
+		}
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return err
+}
+
+// Calls returns the number of calls of method received so far, including
+// failed ones
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
@@ -0,0 +1,89 @@
+package fakereddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fixtures are the initial state of a Server
+type Fixtures struct {
+	SubReddits []*pb.SubReddit
+	Posts      []*pb.Post
+	Comments   []*pb.Comment
+}
+
+// DefaultFixtures returns the contents of the example database
+func DefaultFixtures() Fixtures {
+	str := func(s string) *string { return &s }
+	return Fixtures{
+		SubReddits: []*pb.SubReddit{
+			{Id: 1, Name: "r/aww", State: pb.SubRedditState_PUBLIC, Tags: []string{"tag-1", "tag-2", "tag-3"}},
+			{Id: 2, Name: "r/funny", State: pb.SubRedditState_PRIVATE, Tags: []string{"tag-4", "tag-5", "tag-6"}},
+		},
+		Posts: []*pb.Post{
+			{
+				Id: 1, Title: "Cat Video", Content: "Interdum efficitur massa enim sodales penatibus tempor felis finibus senectus",
+				SubReddit: &pb.SubReddit{Id: 1}, VideoURL: str("https://giphy.com/clips/IsDjNQPc4weWPEwhWm"),
+				Author: &pb.User{Id: 1}, Score: 2, State: pb.PostState_NORMAL_POST,
+			},
+			{
+				Id: 2, Title: "Cat Image", Content: "Ullamcorper mollis hendrerit netus urna pharetra lectus tempor justo vel",
+				SubReddit: &pb.SubReddit{Id: 2}, ImageURL: str("https://i.imgur.com/Ozgwrq2_d.webp"),
+				State: pb.PostState_LOCKED_POST,
+			},
+		},
+		Comments: []*pb.Comment{
+			{Id: 1, Content: "Fermentum aliquet adipiscing", Author: &pb.User{Id: 2}, Score: 2, State: pb.CommentState_NORMAL_COMMENT, Parent: pb.ContentType_POST, ParentID: 1},
+			{Id: 2, Content: "Massa a litora", Author: &pb.User{Id: 1}, State: pb.CommentState_NORMAL_COMMENT, Parent: pb.ContentType_COMMENT, ParentID: 1},
+			{Id: 3, Content: "Aliquet sodales hendrerit", Author: &pb.User{Id: 2}, State: pb.CommentState_NORMAL_COMMENT, Parent: pb.ContentType_COMMENT, ParentID: 2},
+			{Id: 4, Content: "Aliquet pulvinar mollis", Author: &pb.User{Id: 1}, State: pb.CommentState_NORMAL_COMMENT, Parent: pb.ContentType_POST, ParentID: 2},
+			{Id: 5, Content: "Nascetur euismod aliquet", Author: &pb.User{Id: 2}, State: pb.CommentState_NORMAL_COMMENT, Parent: pb.ContentType_POST, ParentID: 2},
+			{Id: 6, Content: "Massa suscipit gravida", Author: &pb.User{Id: 1}, State: pb.CommentState_LOCKED_COMMENT, Parent: pb.ContentType_POST, ParentID: 2},
+		},
+	}
+}
+
+// LoadFixtures reads fixtures from a JSON object with subReddits, posts and
+// comments arrays, whose elements use the JSON mapping of the messages
+func LoadFixtures(r io.Reader) (Fixtures, error) {
+	var raw struct {
+		SubReddits []json.RawMessage `json:"subReddits"`
+		Posts      []json.RawMessage `json:"posts"`
+		Comments   []json.RawMessage `json:"comments"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return Fixtures{}, fmt.Errorf("decoding fixtures: %w", err)
+	}
+
+	var f Fixtures
+	var err error
+	if f.SubReddits, err = unmarshalAll[pb.SubReddit](raw.SubReddits); err != nil {
+		return Fixtures{}, fmt.Errorf("decoding subreddit fixtures: %w", err)
+	}
+	if f.Posts, err = unmarshalAll[pb.Post](raw.Posts); err != nil {
+		return Fixtures{}, fmt.Errorf("decoding post fixtures: %w", err)
+	}
+	if f.Comments, err = unmarshalAll[pb.Comment](raw.Comments); err != nil {
+		return Fixtures{}, fmt.Errorf("decoding comment fixtures: %w", err)
+	}
+	return f, nil
+}
+
+func unmarshalAll[T any, M interface {
+	*T
+	proto.Message
+}](raw []json.RawMessage) ([]M, error) {
+	messages := make([]M, len(raw))
+	for i, data := range raw {
+		messages[i] = new(T)
+		if err := protojson.Unmarshal(data, messages[i]); err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
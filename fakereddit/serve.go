@@ -0,0 +1,49 @@
+package fakereddit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Serve s in process until the test ends, returning the dial option that
+// connects to it
+func listen(tb testing.TB, s *Server) grpc.DialOption {
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	pb.RegisterRedditServer(gs, s)
+	go gs.Serve(lis)
+	tb.Cleanup(gs.Stop)
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
+
+// Dial serves s in process until the test ends and returns a connection to
+// it, for callers of the generated pb.RedditClient
+func Dial(tb testing.TB, s *Server) *grpc.ClientConn {
+	conn, err := grpc.Dial("passthrough:///fakereddit", listen(tb, s), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		tb.Fatalf("dialing the fake server: %v", err)
+	}
+	tb.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// NewClient serves s in process until the test ends and returns a client
+// connected to it with opts
+func NewClient(tb testing.TB, s *Server, opts ...redditclient.Option) *redditclient.RedditAPIClient {
+	opts = append(opts, redditclient.WithDialOptions(listen(tb, s)))
+	client, err := redditclient.NewRedditAPIClient(opts...)
+	if err != nil {
+		tb.Fatalf("connecting to the fake server: %v", err)
+	}
+	tb.Cleanup(func() { client.Close() })
+	return client
+}
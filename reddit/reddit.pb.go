@@ -571,6 +571,115 @@ func (x *Comment) GetChildren() []*Comment {
 	return nil
 }
 
+// One user, subreddit, post or comment of a dataset exported to a file
+type ExportRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Record:
+	//	*ExportRecord_User
+	//	*ExportRecord_SubReddit
+	//	*ExportRecord_Post
+	//	*ExportRecord_Comment
+	Record isExportRecord_Record `protobuf_oneof:"record"`
+}
+
+func (x *ExportRecord) Reset() {
+	*x = ExportRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRecord) ProtoMessage() {}
+
+func (x *ExportRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRecord.ProtoReflect.Descriptor instead.
+func (*ExportRecord) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *ExportRecord) GetRecord() isExportRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetUser() *User {
+	if x, ok := x.GetRecord().(*ExportRecord_User); ok {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetSubReddit() *SubReddit {
+	if x, ok := x.GetRecord().(*ExportRecord_SubReddit); ok {
+		return x.SubReddit
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetPost() *Post {
+	if x, ok := x.GetRecord().(*ExportRecord_Post); ok {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *ExportRecord) GetComment() *Comment {
+	if x, ok := x.GetRecord().(*ExportRecord_Comment); ok {
+		return x.Comment
+	}
+	return nil
+}
+
+type isExportRecord_Record interface {
+	isExportRecord_Record()
+}
+
+type ExportRecord_User struct {
+	User *User `protobuf:"bytes,1,opt,name=user,proto3,oneof"`
+}
+
+type ExportRecord_SubReddit struct {
+	SubReddit *SubReddit `protobuf:"bytes,2,opt,name=subReddit,proto3,oneof"`
+}
+
+type ExportRecord_Post struct {
+	Post *Post `protobuf:"bytes,3,opt,name=post,proto3,oneof"`
+}
+
+type ExportRecord_Comment struct {
+	Comment *Comment `protobuf:"bytes,4,opt,name=comment,proto3,oneof"`
+}
+
+func (*ExportRecord_User) isExportRecord_Record() {}
+
+func (*ExportRecord_SubReddit) isExportRecord_Record() {}
+
+func (*ExportRecord_Post) isExportRecord_Record() {}
+
+func (*ExportRecord_Comment) isExportRecord_Record() {}
+
 // The request message for creating a post
 type CreatePostRequest struct {
 	state         protoimpl.MessageState
@@ -583,7 +692,7 @@ type CreatePostRequest struct {
 func (x *CreatePostRequest) Reset() {
 	*x = CreatePostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[4]
+		mi := &file_reddit_reddit_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -596,7 +705,7 @@ func (x *CreatePostRequest) String() string {
 func (*CreatePostRequest) ProtoMessage() {}
 
 func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[4]
+	mi := &file_reddit_reddit_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +718,7 @@ func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
 func (*CreatePostRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{4}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreatePostRequest) GetPost() *Post {
@@ -631,7 +740,7 @@ type CreatePostResponse struct {
 func (x *CreatePostResponse) Reset() {
 	*x = CreatePostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[5]
+		mi := &file_reddit_reddit_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -644,7 +753,7 @@ func (x *CreatePostResponse) String() string {
 func (*CreatePostResponse) ProtoMessage() {}
 
 func (x *CreatePostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[5]
+	mi := &file_reddit_reddit_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +766,7 @@ func (x *CreatePostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePostResponse.ProtoReflect.Descriptor instead.
 func (*CreatePostResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{5}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreatePostResponse) GetPost() *Post {
@@ -681,7 +790,7 @@ type VotePostRequest struct {
 func (x *VotePostRequest) Reset() {
 	*x = VotePostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[6]
+		mi := &file_reddit_reddit_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -694,7 +803,7 @@ func (x *VotePostRequest) String() string {
 func (*VotePostRequest) ProtoMessage() {}
 
 func (x *VotePostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[6]
+	mi := &file_reddit_reddit_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +816,7 @@ func (x *VotePostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VotePostRequest.ProtoReflect.Descriptor instead.
 func (*VotePostRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{6}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VotePostRequest) GetPostID() int32 {
@@ -743,7 +852,7 @@ type VotePostResponse struct {
 func (x *VotePostResponse) Reset() {
 	*x = VotePostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[7]
+		mi := &file_reddit_reddit_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -756,7 +865,7 @@ func (x *VotePostResponse) String() string {
 func (*VotePostResponse) ProtoMessage() {}
 
 func (x *VotePostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[7]
+	mi := &file_reddit_reddit_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -769,7 +878,7 @@ func (x *VotePostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VotePostResponse.ProtoReflect.Descriptor instead.
 func (*VotePostResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{7}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VotePostResponse) GetScore() int32 {
@@ -791,7 +900,7 @@ type GetPostRequest struct {
 func (x *GetPostRequest) Reset() {
 	*x = GetPostRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[8]
+		mi := &file_reddit_reddit_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -804,7 +913,7 @@ func (x *GetPostRequest) String() string {
 func (*GetPostRequest) ProtoMessage() {}
 
 func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[8]
+	mi := &file_reddit_reddit_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,7 +926,7 @@ func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
 func (*GetPostRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{8}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPostRequest) GetPostID() int32 {
@@ -839,7 +948,7 @@ type GetPostResponse struct {
 func (x *GetPostResponse) Reset() {
 	*x = GetPostResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[9]
+		mi := &file_reddit_reddit_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -852,7 +961,7 @@ func (x *GetPostResponse) String() string {
 func (*GetPostResponse) ProtoMessage() {}
 
 func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[9]
+	mi := &file_reddit_reddit_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -865,7 +974,7 @@ func (x *GetPostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPostResponse.ProtoReflect.Descriptor instead.
 func (*GetPostResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{9}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPostResponse) GetPost() *Post {
@@ -887,7 +996,7 @@ type CreateCommentRequest struct {
 func (x *CreateCommentRequest) Reset() {
 	*x = CreateCommentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[10]
+		mi := &file_reddit_reddit_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -900,7 +1009,7 @@ func (x *CreateCommentRequest) String() string {
 func (*CreateCommentRequest) ProtoMessage() {}
 
 func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[10]
+	mi := &file_reddit_reddit_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1022,7 @@ func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
 func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{10}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateCommentRequest) GetComment() *Comment {
@@ -935,7 +1044,7 @@ type CreateCommentResponse struct {
 func (x *CreateCommentResponse) Reset() {
 	*x = CreateCommentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[11]
+		mi := &file_reddit_reddit_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -948,7 +1057,7 @@ func (x *CreateCommentResponse) String() string {
 func (*CreateCommentResponse) ProtoMessage() {}
 
 func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[11]
+	mi := &file_reddit_reddit_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +1070,7 @@ func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCommentResponse.ProtoReflect.Descriptor instead.
 func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{11}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreateCommentResponse) GetComment() *Comment {
@@ -985,7 +1094,7 @@ type VoteCommentRequest struct {
 func (x *VoteCommentRequest) Reset() {
 	*x = VoteCommentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[12]
+		mi := &file_reddit_reddit_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -998,7 +1107,7 @@ func (x *VoteCommentRequest) String() string {
 func (*VoteCommentRequest) ProtoMessage() {}
 
 func (x *VoteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[12]
+	mi := &file_reddit_reddit_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1120,7 @@ func (x *VoteCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VoteCommentRequest.ProtoReflect.Descriptor instead.
 func (*VoteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{12}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *VoteCommentRequest) GetCommentID() int32 {
@@ -1047,7 +1156,7 @@ type VoteCommentResponse struct {
 func (x *VoteCommentResponse) Reset() {
 	*x = VoteCommentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[13]
+		mi := &file_reddit_reddit_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1060,7 +1169,7 @@ func (x *VoteCommentResponse) String() string {
 func (*VoteCommentResponse) ProtoMessage() {}
 
 func (x *VoteCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[13]
+	mi := &file_reddit_reddit_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1073,7 +1182,7 @@ func (x *VoteCommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VoteCommentResponse.ProtoReflect.Descriptor instead.
 func (*VoteCommentResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{13}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *VoteCommentResponse) GetScore() int32 {
@@ -1095,7 +1204,7 @@ type GetCommentRequest struct {
 func (x *GetCommentRequest) Reset() {
 	*x = GetCommentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[14]
+		mi := &file_reddit_reddit_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1108,7 +1217,7 @@ func (x *GetCommentRequest) String() string {
 func (*GetCommentRequest) ProtoMessage() {}
 
 func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[14]
+	mi := &file_reddit_reddit_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1121,7 +1230,7 @@ func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCommentRequest.ProtoReflect.Descriptor instead.
 func (*GetCommentRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{14}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetCommentRequest) GetCommentID() int32 {
@@ -1143,7 +1252,7 @@ type GetCommentResponse struct {
 func (x *GetCommentResponse) Reset() {
 	*x = GetCommentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[15]
+		mi := &file_reddit_reddit_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1156,7 +1265,7 @@ func (x *GetCommentResponse) String() string {
 func (*GetCommentResponse) ProtoMessage() {}
 
 func (x *GetCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[15]
+	mi := &file_reddit_reddit_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,7 +1278,7 @@ func (x *GetCommentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCommentResponse.ProtoReflect.Descriptor instead.
 func (*GetCommentResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{15}
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetCommentResponse) GetComment() *Comment {
@@ -1179,33 +1288,32 @@ func (x *GetCommentResponse) GetComment() *Comment {
 	return nil
 }
 
-// The request message for retrieving a list of N most upvoted comments under a post
-type GetTopCommentsRequest struct {
+// The request message for retrieving several posts, of at most 100 IDs
+type BatchGetPostsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PostID   int32 `protobuf:"varint,1,opt,name=postID,proto3" json:"postID,omitempty"`
-	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PostIDs []int32 `protobuf:"varint,1,rep,packed,name=postIDs,proto3" json:"postIDs,omitempty"`
 }
 
-func (x *GetTopCommentsRequest) Reset() {
-	*x = GetTopCommentsRequest{}
+func (x *BatchGetPostsRequest) Reset() {
+	*x = BatchGetPostsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[16]
+		mi := &file_reddit_reddit_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTopCommentsRequest) String() string {
+func (x *BatchGetPostsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopCommentsRequest) ProtoMessage() {}
+func (*BatchGetPostsRequest) ProtoMessage() {}
 
-func (x *GetTopCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[16]
+func (x *BatchGetPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1216,51 +1324,45 @@ func (x *GetTopCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopCommentsRequest.ProtoReflect.Descriptor instead.
-func (*GetTopCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *GetTopCommentsRequest) GetPostID() int32 {
-	if x != nil {
-		return x.PostID
-	}
-	return 0
+// Deprecated: Use BatchGetPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetTopCommentsRequest) GetQuantity() int32 {
+func (x *BatchGetPostsRequest) GetPostIDs() []int32 {
 	if x != nil {
-		return x.Quantity
+		return x.PostIDs
 	}
-	return 0
+	return nil
 }
 
-// The response message for retrieving a list of N most upvoted comments under a post
-type GetTopCommentsResponse struct {
+// The response message for retrieving several posts, with a result for
+// each requested ID in the same order
+type BatchGetPostsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Results []*PostResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *GetTopCommentsResponse) Reset() {
-	*x = GetTopCommentsResponse{}
+func (x *BatchGetPostsResponse) Reset() {
+	*x = BatchGetPostsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[17]
+		mi := &file_reddit_reddit_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTopCommentsResponse) String() string {
+func (x *BatchGetPostsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopCommentsResponse) ProtoMessage() {}
+func (*BatchGetPostsResponse) ProtoMessage() {}
 
-func (x *GetTopCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[17]
+func (x *BatchGetPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,45 +1373,46 @@ func (x *GetTopCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopCommentsResponse.ProtoReflect.Descriptor instead.
-func (*GetTopCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use BatchGetPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetTopCommentsResponse) GetComments() []*Comment {
+func (x *BatchGetPostsResponse) GetResults() []*PostResult {
 	if x != nil {
-		return x.Comments
+		return x.Results
 	}
 	return nil
 }
 
-// The request message for expanding a comment branch
-type ExpandCommentBranchRequest struct {
+// The post of an ID in a batch
+type PostResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CommentID int32 `protobuf:"varint,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
-	Quantity  int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PostID   int32 `protobuf:"varint,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	Post     *Post `protobuf:"bytes,2,opt,name=post,proto3" json:"post,omitempty"` // unset when not found
+	NotFound bool  `protobuf:"varint,3,opt,name=notFound,proto3" json:"notFound,omitempty"`
 }
 
-func (x *ExpandCommentBranchRequest) Reset() {
-	*x = ExpandCommentBranchRequest{}
+func (x *PostResult) Reset() {
+	*x = PostResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[18]
+		mi := &file_reddit_reddit_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExpandCommentBranchRequest) String() string {
+func (x *PostResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpandCommentBranchRequest) ProtoMessage() {}
+func (*PostResult) ProtoMessage() {}
 
-func (x *ExpandCommentBranchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[18]
+func (x *PostResult) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,51 +1423,58 @@ func (x *ExpandCommentBranchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpandCommentBranchRequest.ProtoReflect.Descriptor instead.
-func (*ExpandCommentBranchRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PostResult.ProtoReflect.Descriptor instead.
+func (*PostResult) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ExpandCommentBranchRequest) GetCommentID() int32 {
+func (x *PostResult) GetPostID() int32 {
 	if x != nil {
-		return x.CommentID
+		return x.PostID
 	}
 	return 0
 }
 
-func (x *ExpandCommentBranchRequest) GetQuantity() int32 {
+func (x *PostResult) GetPost() *Post {
 	if x != nil {
-		return x.Quantity
+		return x.Post
 	}
-	return 0
+	return nil
 }
 
-// The response message for expanding a comment branch
-type ExpandCommentBranchResponse struct {
+func (x *PostResult) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+// The request message for retrieving several comments, of at most 100 IDs
+type BatchGetCommentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	CommentIDs []int32 `protobuf:"varint,1,rep,packed,name=commentIDs,proto3" json:"commentIDs,omitempty"`
 }
 
-func (x *ExpandCommentBranchResponse) Reset() {
-	*x = ExpandCommentBranchResponse{}
+func (x *BatchGetCommentsRequest) Reset() {
+	*x = BatchGetCommentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[19]
+		mi := &file_reddit_reddit_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExpandCommentBranchResponse) String() string {
+func (x *BatchGetCommentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExpandCommentBranchResponse) ProtoMessage() {}
+func (*BatchGetCommentsRequest) ProtoMessage() {}
 
-func (x *ExpandCommentBranchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[19]
+func (x *BatchGetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1375,45 +1485,45 @@ func (x *ExpandCommentBranchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExpandCommentBranchResponse.ProtoReflect.Descriptor instead.
-func (*ExpandCommentBranchResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use BatchGetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ExpandCommentBranchResponse) GetComments() []*Comment {
+func (x *BatchGetCommentsRequest) GetCommentIDs() []int32 {
 	if x != nil {
-		return x.Comments
+		return x.CommentIDs
 	}
 	return nil
 }
 
-// The request message for monitoring updates
-type MonitorUpdatesRequest struct {
+// The response message for retrieving several comments, with a result for
+// each requested ID in the same order
+type BatchGetCommentsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ContentType ContentType `protobuf:"varint,1,opt,name=contentType,proto3,enum=reddit.ContentType" json:"contentType,omitempty"`
-	ContentID   int32       `protobuf:"varint,2,opt,name=contentID,proto3" json:"contentID,omitempty"`
+	Results []*CommentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *MonitorUpdatesRequest) Reset() {
-	*x = MonitorUpdatesRequest{}
+func (x *BatchGetCommentsResponse) Reset() {
+	*x = BatchGetCommentsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[20]
+		mi := &file_reddit_reddit_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MonitorUpdatesRequest) String() string {
+func (x *BatchGetCommentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonitorUpdatesRequest) ProtoMessage() {}
+func (*BatchGetCommentsResponse) ProtoMessage() {}
 
-func (x *MonitorUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[20]
+func (x *BatchGetCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,53 +1534,886 @@ func (x *MonitorUpdatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonitorUpdatesRequest.ProtoReflect.Descriptor instead.
-func (*MonitorUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use BatchGetCommentsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *MonitorUpdatesRequest) GetContentType() ContentType {
+func (x *BatchGetCommentsResponse) GetResults() []*CommentResult {
 	if x != nil {
-		return x.ContentType
+		return x.Results
 	}
-	return ContentType_CONTENTTYPE_UNSPECIFIED
+	return nil
+}
+
+// The comment of an ID in a batch
+type CommentResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID int32    `protobuf:"varint,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	Comment   *Comment `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"` // unset when not found
+	NotFound  bool     `protobuf:"varint,3,opt,name=notFound,proto3" json:"notFound,omitempty"`
+}
+
+func (x *CommentResult) Reset() {
+	*x = CommentResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentResult) ProtoMessage() {}
+
+func (x *CommentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentResult.ProtoReflect.Descriptor instead.
+func (*CommentResult) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CommentResult) GetCommentID() int32 {
+	if x != nil {
+		return x.CommentID
+	}
+	return 0
+}
+
+func (x *CommentResult) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+func (x *CommentResult) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+// The request message for retrieving a list of N most upvoted comments under a post
+type GetTopCommentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PostID   int32 `protobuf:"varint,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	Quantity int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *GetTopCommentsRequest) Reset() {
+	*x = GetTopCommentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopCommentsRequest) ProtoMessage() {}
+
+func (x *GetTopCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetTopCommentsRequest) GetPostID() int32 {
+	if x != nil {
+		return x.PostID
+	}
+	return 0
+}
+
+func (x *GetTopCommentsRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// The response message for retrieving a list of N most upvoted comments under a post
+type GetTopCommentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+}
+
+func (x *GetTopCommentsResponse) Reset() {
+	*x = GetTopCommentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopCommentsResponse) ProtoMessage() {}
+
+func (x *GetTopCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopCommentsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTopCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+// The request message for expanding a comment branch
+type ExpandCommentBranchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID int32 `protobuf:"varint,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	Quantity  int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *ExpandCommentBranchRequest) Reset() {
+	*x = ExpandCommentBranchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpandCommentBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandCommentBranchRequest) ProtoMessage() {}
+
+func (x *ExpandCommentBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandCommentBranchRequest.ProtoReflect.Descriptor instead.
+func (*ExpandCommentBranchRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExpandCommentBranchRequest) GetCommentID() int32 {
+	if x != nil {
+		return x.CommentID
+	}
+	return 0
+}
+
+func (x *ExpandCommentBranchRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// The response message for expanding a comment branch
+type ExpandCommentBranchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+}
+
+func (x *ExpandCommentBranchResponse) Reset() {
+	*x = ExpandCommentBranchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpandCommentBranchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandCommentBranchResponse) ProtoMessage() {}
+
+func (x *ExpandCommentBranchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandCommentBranchResponse.ProtoReflect.Descriptor instead.
+func (*ExpandCommentBranchResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExpandCommentBranchResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+// The request message for monitoring updates
+type MonitorUpdatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContentType ContentType `protobuf:"varint,1,opt,name=contentType,proto3,enum=reddit.ContentType" json:"contentType,omitempty"`
+	ContentID   int32       `protobuf:"varint,2,opt,name=contentID,proto3" json:"contentID,omitempty"`
+	Unsubscribe bool        `protobuf:"varint,3,opt,name=unsubscribe,proto3" json:"unsubscribe,omitempty"` // stop monitoring the content instead
+}
+
+func (x *MonitorUpdatesRequest) Reset() {
+	*x = MonitorUpdatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonitorUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitorUpdatesRequest) ProtoMessage() {}
+
+func (x *MonitorUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitorUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*MonitorUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MonitorUpdatesRequest) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_CONTENTTYPE_UNSPECIFIED
 }
 
 func (x *MonitorUpdatesRequest) GetContentID() int32 {
 	if x != nil {
-		return x.ContentID
+		return x.ContentID
+	}
+	return 0
+}
+
+func (x *MonitorUpdatesRequest) GetUnsubscribe() bool {
+	if x != nil {
+		return x.Unsubscribe
+	}
+	return false
+}
+
+// The response message for monitoring updates
+type MonitorUpdatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContentType ContentType `protobuf:"varint,1,opt,name=contentType,proto3,enum=reddit.ContentType" json:"contentType,omitempty"`
+	ContentID   int32       `protobuf:"varint,2,opt,name=contentID,proto3" json:"contentID,omitempty"`
+	Score       int32       `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	NotFound    bool        `protobuf:"varint,4,opt,name=notFound,proto3" json:"notFound,omitempty"` // the content does not exist and is no longer monitored
+}
+
+func (x *MonitorUpdatesResponse) Reset() {
+	*x = MonitorUpdatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MonitorUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitorUpdatesResponse) ProtoMessage() {}
+
+func (x *MonitorUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitorUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*MonitorUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MonitorUpdatesResponse) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_CONTENTTYPE_UNSPECIFIED
+}
+
+func (x *MonitorUpdatesResponse) GetContentID() int32 {
+	if x != nil {
+		return x.ContentID
+	}
+	return 0
+}
+
+func (x *MonitorUpdatesResponse) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MonitorUpdatesResponse) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+// The request message for watching updates
+type WatchUpdatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscriptions []*MonitorUpdatesRequest `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+}
+
+func (x *WatchUpdatesRequest) Reset() {
+	*x = WatchUpdatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUpdatesRequest) ProtoMessage() {}
+
+func (x *WatchUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*WatchUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WatchUpdatesRequest) GetSubscriptions() []*MonitorUpdatesRequest {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+// The request message for listing posts. Hidden posts are left out, and
+// pages continue after the post of the page token.
+type ListPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubRedditID int32  `protobuf:"varint,1,opt,name=subRedditID,proto3" json:"subRedditID,omitempty"` // 0 for every subreddit
+	PageSize    int32  `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken   string `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListPostsRequest) Reset() {
+	*x = ListPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsRequest) ProtoMessage() {}
+
+func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListPostsRequest) GetSubRedditID() int32 {
+	if x != nil {
+		return x.SubRedditID
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// The response message for listing posts
+type ListPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Posts         []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	NextPageToken string  `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
+}
+
+func (x *ListPostsResponse) Reset() {
+	*x = ListPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsResponse) ProtoMessage() {}
+
+func (x *ListPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListPostsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// The request message for searching posts
+type SearchPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query       string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	SubRedditID int32  `protobuf:"varint,2,opt,name=subRedditID,proto3" json:"subRedditID,omitempty"` // 0 for every subreddit
+	Limit       int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SearchPostsRequest) Reset() {
+	*x = SearchPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsRequest) ProtoMessage() {}
+
+func (x *SearchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SearchPostsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPostsRequest) GetSubRedditID() int32 {
+	if x != nil {
+		return x.SubRedditID
+	}
+	return 0
+}
+
+func (x *SearchPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The response message for searching posts
+type SearchPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Posts []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+}
+
+func (x *SearchPostsResponse) Reset() {
+	*x = SearchPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPostsResponse) ProtoMessage() {}
+
+func (x *SearchPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPostsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPostsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+// The request message for retrieving a subreddit
+type GetSubRedditRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubRedditID int32 `protobuf:"varint,1,opt,name=subRedditID,proto3" json:"subRedditID,omitempty"`
+}
+
+func (x *GetSubRedditRequest) Reset() {
+	*x = GetSubRedditRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubRedditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubRedditRequest) ProtoMessage() {}
+
+func (x *GetSubRedditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubRedditRequest.ProtoReflect.Descriptor instead.
+func (*GetSubRedditRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetSubRedditRequest) GetSubRedditID() int32 {
+	if x != nil {
+		return x.SubRedditID
+	}
+	return 0
+}
+
+// The response message for retrieving a subreddit
+type GetSubRedditResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubReddit *SubReddit `protobuf:"bytes,1,opt,name=subReddit,proto3" json:"subReddit,omitempty"`
+}
+
+func (x *GetSubRedditResponse) Reset() {
+	*x = GetSubRedditResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubRedditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubRedditResponse) ProtoMessage() {}
+
+func (x *GetSubRedditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubRedditResponse.ProtoReflect.Descriptor instead.
+func (*GetSubRedditResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetSubRedditResponse) GetSubReddit() *SubReddit {
+	if x != nil {
+		return x.SubReddit
+	}
+	return nil
+}
+
+// The request message for listing subreddits
+type ListSubRedditsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int32  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+}
+
+func (x *ListSubRedditsRequest) Reset() {
+	*x = ListSubRedditsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_reddit_reddit_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSubRedditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubRedditsRequest) ProtoMessage() {}
+
+func (x *ListSubRedditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubRedditsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubRedditsRequest) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListSubRedditsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
-// The response message for monitoring updates
-type MonitorUpdatesResponse struct {
+func (x *ListSubRedditsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// The response message for listing subreddits
+type ListSubRedditsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ContentType ContentType `protobuf:"varint,1,opt,name=contentType,proto3,enum=reddit.ContentType" json:"contentType,omitempty"`
-	ContentID   int32       `protobuf:"varint,2,opt,name=contentID,proto3" json:"contentID,omitempty"`
-	Score       int32       `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	SubReddits    []*SubReddit `protobuf:"bytes,1,rep,name=subReddits,proto3" json:"subReddits,omitempty"`
+	NextPageToken string       `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
 }
 
-func (x *MonitorUpdatesResponse) Reset() {
-	*x = MonitorUpdatesResponse{}
+func (x *ListSubRedditsResponse) Reset() {
+	*x = ListSubRedditsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_reddit_reddit_proto_msgTypes[21]
+		mi := &file_reddit_reddit_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MonitorUpdatesResponse) String() string {
+func (x *ListSubRedditsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonitorUpdatesResponse) ProtoMessage() {}
+func (*ListSubRedditsResponse) ProtoMessage() {}
 
-func (x *MonitorUpdatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reddit_reddit_proto_msgTypes[21]
+func (x *ListSubRedditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reddit_reddit_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1481,30 +2424,23 @@ func (x *MonitorUpdatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonitorUpdatesResponse.ProtoReflect.Descriptor instead.
-func (*MonitorUpdatesResponse) Descriptor() ([]byte, []int) {
-	return file_reddit_reddit_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *MonitorUpdatesResponse) GetContentType() ContentType {
-	if x != nil {
-		return x.ContentType
-	}
-	return ContentType_CONTENTTYPE_UNSPECIFIED
+// Deprecated: Use ListSubRedditsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubRedditsResponse) Descriptor() ([]byte, []int) {
+	return file_reddit_reddit_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *MonitorUpdatesResponse) GetContentID() int32 {
+func (x *ListSubRedditsResponse) GetSubReddits() []*SubReddit {
 	if x != nil {
-		return x.ContentID
+		return x.SubReddits
 	}
-	return 0
+	return nil
 }
 
-func (x *MonitorUpdatesResponse) GetScore() int32 {
+func (x *ListSubRedditsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Score
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
 var File_reddit_reddit_proto protoreflect.FileDescriptor
@@ -1567,156 +2503,285 @@ var file_reddit_reddit_proto_rawDesc = []byte{
 	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x2b, 0x0a, 0x08,
 	0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
 	0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
-	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0x35, 0x0a, 0x11, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20,
-	0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72,
-	0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74,
-	0x22, 0x36, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f,
-	0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x22, 0x5b, 0x0a, 0x0f, 0x56, 0x6f, 0x74, 0x65,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x6f, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73,
-	0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a,
-	0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75,
-	0x70, 0x76, 0x6f, 0x74, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22,
-	0x28, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x0f, 0x47, 0x65, 0x74,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x04,
-	0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64,
-	0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x22, 0x41,
-	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x42, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65,
-	0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x64, 0x0a, 0x12, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
-	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6f, 0x74,
-	0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x6f, 0x74, 0x65,
-	0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x22, 0x2b, 0x0a, 0x13, 0x56,
-	0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x31, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
-	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x3f, 0x0a, 0x12, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x4b, 0x0a, 0x15,
-	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a,
-	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x45, 0x0a, 0x16, 0x47, 0x65, 0x74,
-	0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x22, 0x56, 0x0a, 0x1a, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0xc0, 0x01, 0x0a, 0x0c, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x31,
+	0x0a, 0x09, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x12, 0x22, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x04, 0x70, 0x6f, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x35, 0x0a, 0x11,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70,
+	0x6f, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x70, 0x6f, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x22, 0x5b, 0x0a, 0x0f, 0x56,
+	0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x56, 0x6f, 0x74, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x22, 0x28, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x20, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73,
+	0x74, 0x22, 0x41, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64,
+	0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0x42, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x64, 0x0a, 0x12, 0x56, 0x6f, 0x74, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
 	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08,
-	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x4a, 0x0a, 0x1b, 0x45, 0x78, 0x70, 0x61,
-	0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64,
-	0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x22, 0x6c, 0x0a, 0x15, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49,
-	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x49, 0x44, 0x22, 0x83, 0x01, 0x0a, 0x16, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49,
-	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x2a, 0x55, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x52,
-	0x65, 0x64, 0x64, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x55,
-	0x42, 0x52, 0x45, 0x44, 0x44, 0x49, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55,
-	0x42, 0x4c, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54,
-	0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x49, 0x44, 0x44, 0x45, 0x4e, 0x10, 0x03, 0x2a,
-	0x59, 0x0a, 0x09, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x15,
-	0x50, 0x4f, 0x53, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x4f, 0x52, 0x4d, 0x41,
-	0x4c, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x4f, 0x43, 0x4b,
-	0x45, 0x44, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x49, 0x44,
-	0x44, 0x45, 0x4e, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x2a, 0x54, 0x0a, 0x0c, 0x43, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f,
-	0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x52, 0x4d,
-	0x41, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
-	0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x02,
-	0x2a, 0x41, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
-	0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e,
-	0x54, 0x10, 0x02, 0x32, 0xbb, 0x05, 0x0a, 0x06, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x12, 0x45,
-	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x72,
-	0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x12, 0x17, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x65, 0x64,
-	0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73,
-	0x74, 0x12, 0x16, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f,
-	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x64, 0x64,
-	0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74,
-	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45,
-	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x72,
-	0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
-	0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76,
+	0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x22, 0x2b,
+	0x0a, 0x13, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x31, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x3f,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x30, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x49,
+	0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44,
+	0x73, 0x22, 0x45, 0x0a, 0x15, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0a, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x20,
+	0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72,
+	0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x39, 0x0a, 0x17,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x73, 0x22, 0x4b, 0x0a, 0x18, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0x74, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x44, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x4b, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x6f,
+	0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x56,
+	0x0a, 0x1a, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x4a, 0x0a, 0x1b, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x15, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x16, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74,
+	0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x74,
+	0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x5a, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0d,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x4d, 0x6f, 0x6e,
+	0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x6e, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x52,
+	0x65, 0x64, 0x64, 0x69, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x5d, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x62, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x49, 0x44, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x39, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x70,
+	0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x65, 0x64,
+	0x64, 0x69, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x22,
+	0x37, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64,
+	0x64, 0x69, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x75, 0x62,
+	0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x49, 0x44, 0x22, 0x47, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2f, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x75, 0x62,
+	0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x52, 0x09, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x22, 0x51, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x52,
+	0x65, 0x64, 0x64, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x0a, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x52,
+	0x65, 0x64, 0x64, 0x69, 0x74, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x2a, 0x55, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x55, 0x42,
+	0x52, 0x45, 0x44, 0x44, 0x49, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42,
+	0x4c, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45,
+	0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x49, 0x44, 0x44, 0x45, 0x4e, 0x10, 0x03, 0x2a, 0x59,
+	0x0a, 0x09, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x50,
+	0x4f, 0x53, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c,
+	0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x4f, 0x43, 0x4b, 0x45,
+	0x44, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x49, 0x44, 0x44,
+	0x45, 0x4e, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x2a, 0x54, 0x0a, 0x0c, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4d,
+	0x4d, 0x45, 0x4e, 0x54, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x52, 0x4d, 0x41,
+	0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4c,
+	0x4f, 0x43, 0x4b, 0x45, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x2a,
+	0x41, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b,
+	0x0a, 0x17, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50,
+	0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54,
+	0x10, 0x02, 0x32, 0xe3, 0x09, 0x0a, 0x06, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x12, 0x45, 0x0a,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x12, 0x17, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74,
+	0x12, 0x16, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x72, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x60, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x22, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x65,
+	0x64, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x55, 0x0a, 0x0e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x4d, 0x6f, 0x6e,
+	0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e,
-	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x61,
-	0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12,
-	0x22, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x43,
-	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x45, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0e, 0x4d, 0x6f,
-	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x72,
-	0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65,
-	0x64, 0x64, 0x69, 0x74, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30,
-	0x01, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x74, 0x6f, 0x6d, 0x79, 0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x2f, 0x67, 0x72, 0x70, 0x63,
-	0x2d, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2f, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x09, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48,
+	0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x2e,
+	0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x64, 0x64,
+	0x69, 0x74, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62,
+	0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x52, 0x65, 0x64, 0x64, 0x69, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x6f, 0x6d, 0x79, 0x30, 0x30, 0x30, 0x30, 0x30,
+	0x30, 0x30, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x72, 0x65, 0x64, 0x64, 0x69, 0x74, 0x2f, 0x72,
+	0x65, 0x64, 0x64, 0x69, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1732,7 +2797,7 @@ func file_reddit_reddit_proto_rawDescGZIP() []byte {
 }
 
 var file_reddit_reddit_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_reddit_reddit_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_reddit_reddit_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_reddit_reddit_proto_goTypes = []interface{}{
 	(SubRedditState)(0),                 // 0: reddit.SubRedditState
 	(PostState)(0),                      // 1: reddit.PostState
@@ -1742,70 +2807,113 @@ var file_reddit_reddit_proto_goTypes = []interface{}{
 	(*SubReddit)(nil),                   // 5: reddit.SubReddit
 	(*Post)(nil),                        // 6: reddit.Post
 	(*Comment)(nil),                     // 7: reddit.Comment
-	(*CreatePostRequest)(nil),           // 8: reddit.CreatePostRequest
-	(*CreatePostResponse)(nil),          // 9: reddit.CreatePostResponse
-	(*VotePostRequest)(nil),             // 10: reddit.VotePostRequest
-	(*VotePostResponse)(nil),            // 11: reddit.VotePostResponse
-	(*GetPostRequest)(nil),              // 12: reddit.GetPostRequest
-	(*GetPostResponse)(nil),             // 13: reddit.GetPostResponse
-	(*CreateCommentRequest)(nil),        // 14: reddit.CreateCommentRequest
-	(*CreateCommentResponse)(nil),       // 15: reddit.CreateCommentResponse
-	(*VoteCommentRequest)(nil),          // 16: reddit.VoteCommentRequest
-	(*VoteCommentResponse)(nil),         // 17: reddit.VoteCommentResponse
-	(*GetCommentRequest)(nil),           // 18: reddit.GetCommentRequest
-	(*GetCommentResponse)(nil),          // 19: reddit.GetCommentResponse
-	(*GetTopCommentsRequest)(nil),       // 20: reddit.GetTopCommentsRequest
-	(*GetTopCommentsResponse)(nil),      // 21: reddit.GetTopCommentsResponse
-	(*ExpandCommentBranchRequest)(nil),  // 22: reddit.ExpandCommentBranchRequest
-	(*ExpandCommentBranchResponse)(nil), // 23: reddit.ExpandCommentBranchResponse
-	(*MonitorUpdatesRequest)(nil),       // 24: reddit.MonitorUpdatesRequest
-	(*MonitorUpdatesResponse)(nil),      // 25: reddit.MonitorUpdatesResponse
-	(*date.Date)(nil),                   // 26: google.type.Date
+	(*ExportRecord)(nil),                // 8: reddit.ExportRecord
+	(*CreatePostRequest)(nil),           // 9: reddit.CreatePostRequest
+	(*CreatePostResponse)(nil),          // 10: reddit.CreatePostResponse
+	(*VotePostRequest)(nil),             // 11: reddit.VotePostRequest
+	(*VotePostResponse)(nil),            // 12: reddit.VotePostResponse
+	(*GetPostRequest)(nil),              // 13: reddit.GetPostRequest
+	(*GetPostResponse)(nil),             // 14: reddit.GetPostResponse
+	(*CreateCommentRequest)(nil),        // 15: reddit.CreateCommentRequest
+	(*CreateCommentResponse)(nil),       // 16: reddit.CreateCommentResponse
+	(*VoteCommentRequest)(nil),          // 17: reddit.VoteCommentRequest
+	(*VoteCommentResponse)(nil),         // 18: reddit.VoteCommentResponse
+	(*GetCommentRequest)(nil),           // 19: reddit.GetCommentRequest
+	(*GetCommentResponse)(nil),          // 20: reddit.GetCommentResponse
+	(*BatchGetPostsRequest)(nil),        // 21: reddit.BatchGetPostsRequest
+	(*BatchGetPostsResponse)(nil),       // 22: reddit.BatchGetPostsResponse
+	(*PostResult)(nil),                  // 23: reddit.PostResult
+	(*BatchGetCommentsRequest)(nil),     // 24: reddit.BatchGetCommentsRequest
+	(*BatchGetCommentsResponse)(nil),    // 25: reddit.BatchGetCommentsResponse
+	(*CommentResult)(nil),               // 26: reddit.CommentResult
+	(*GetTopCommentsRequest)(nil),       // 27: reddit.GetTopCommentsRequest
+	(*GetTopCommentsResponse)(nil),      // 28: reddit.GetTopCommentsResponse
+	(*ExpandCommentBranchRequest)(nil),  // 29: reddit.ExpandCommentBranchRequest
+	(*ExpandCommentBranchResponse)(nil), // 30: reddit.ExpandCommentBranchResponse
+	(*MonitorUpdatesRequest)(nil),       // 31: reddit.MonitorUpdatesRequest
+	(*MonitorUpdatesResponse)(nil),      // 32: reddit.MonitorUpdatesResponse
+	(*WatchUpdatesRequest)(nil),         // 33: reddit.WatchUpdatesRequest
+	(*ListPostsRequest)(nil),            // 34: reddit.ListPostsRequest
+	(*ListPostsResponse)(nil),           // 35: reddit.ListPostsResponse
+	(*SearchPostsRequest)(nil),          // 36: reddit.SearchPostsRequest
+	(*SearchPostsResponse)(nil),         // 37: reddit.SearchPostsResponse
+	(*GetSubRedditRequest)(nil),         // 38: reddit.GetSubRedditRequest
+	(*GetSubRedditResponse)(nil),        // 39: reddit.GetSubRedditResponse
+	(*ListSubRedditsRequest)(nil),       // 40: reddit.ListSubRedditsRequest
+	(*ListSubRedditsResponse)(nil),      // 41: reddit.ListSubRedditsResponse
+	(*date.Date)(nil),                   // 42: google.type.Date
 }
 var file_reddit_reddit_proto_depIdxs = []int32{
 	0,  // 0: reddit.SubReddit.state:type_name -> reddit.SubRedditState
 	5,  // 1: reddit.Post.subReddit:type_name -> reddit.SubReddit
 	4,  // 2: reddit.Post.author:type_name -> reddit.User
 	1,  // 3: reddit.Post.state:type_name -> reddit.PostState
-	26, // 4: reddit.Post.publicationDate:type_name -> google.type.Date
+	42, // 4: reddit.Post.publicationDate:type_name -> google.type.Date
 	4,  // 5: reddit.Comment.author:type_name -> reddit.User
 	2,  // 6: reddit.Comment.state:type_name -> reddit.CommentState
-	26, // 7: reddit.Comment.publicationDate:type_name -> google.type.Date
+	42, // 7: reddit.Comment.publicationDate:type_name -> google.type.Date
 	3,  // 8: reddit.Comment.parent:type_name -> reddit.ContentType
 	7,  // 9: reddit.Comment.children:type_name -> reddit.Comment
-	6,  // 10: reddit.CreatePostRequest.post:type_name -> reddit.Post
-	6,  // 11: reddit.CreatePostResponse.post:type_name -> reddit.Post
-	6,  // 12: reddit.GetPostResponse.post:type_name -> reddit.Post
-	7,  // 13: reddit.CreateCommentRequest.comment:type_name -> reddit.Comment
-	7,  // 14: reddit.CreateCommentResponse.comment:type_name -> reddit.Comment
-	7,  // 15: reddit.GetCommentResponse.comment:type_name -> reddit.Comment
-	7,  // 16: reddit.GetTopCommentsResponse.comments:type_name -> reddit.Comment
-	7,  // 17: reddit.ExpandCommentBranchResponse.comments:type_name -> reddit.Comment
-	3,  // 18: reddit.MonitorUpdatesRequest.contentType:type_name -> reddit.ContentType
-	3,  // 19: reddit.MonitorUpdatesResponse.contentType:type_name -> reddit.ContentType
-	8,  // 20: reddit.Reddit.CreatePost:input_type -> reddit.CreatePostRequest
-	10, // 21: reddit.Reddit.VotePost:input_type -> reddit.VotePostRequest
-	12, // 22: reddit.Reddit.GetPost:input_type -> reddit.GetPostRequest
-	14, // 23: reddit.Reddit.CreateComment:input_type -> reddit.CreateCommentRequest
-	16, // 24: reddit.Reddit.VoteComment:input_type -> reddit.VoteCommentRequest
-	18, // 25: reddit.Reddit.GetComment:input_type -> reddit.GetCommentRequest
-	20, // 26: reddit.Reddit.GetTopComments:input_type -> reddit.GetTopCommentsRequest
-	22, // 27: reddit.Reddit.ExpandCommentBranch:input_type -> reddit.ExpandCommentBranchRequest
-	24, // 28: reddit.Reddit.MonitorUpdates:input_type -> reddit.MonitorUpdatesRequest
-	9,  // 29: reddit.Reddit.CreatePost:output_type -> reddit.CreatePostResponse
-	11, // 30: reddit.Reddit.VotePost:output_type -> reddit.VotePostResponse
-	13, // 31: reddit.Reddit.GetPost:output_type -> reddit.GetPostResponse
-	15, // 32: reddit.Reddit.CreateComment:output_type -> reddit.CreateCommentResponse
-	17, // 33: reddit.Reddit.VoteComment:output_type -> reddit.VoteCommentResponse
-	19, // 34: reddit.Reddit.GetComment:output_type -> reddit.GetCommentResponse
-	21, // 35: reddit.Reddit.GetTopComments:output_type -> reddit.GetTopCommentsResponse
-	23, // 36: reddit.Reddit.ExpandCommentBranch:output_type -> reddit.ExpandCommentBranchResponse
-	25, // 37: reddit.Reddit.MonitorUpdates:output_type -> reddit.MonitorUpdatesResponse
-	29, // [29:38] is the sub-list for method output_type
-	20, // [20:29] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	4,  // 10: reddit.ExportRecord.user:type_name -> reddit.User
+	5,  // 11: reddit.ExportRecord.subReddit:type_name -> reddit.SubReddit
+	6,  // 12: reddit.ExportRecord.post:type_name -> reddit.Post
+	7,  // 13: reddit.ExportRecord.comment:type_name -> reddit.Comment
+	6,  // 14: reddit.CreatePostRequest.post:type_name -> reddit.Post
+	6,  // 15: reddit.CreatePostResponse.post:type_name -> reddit.Post
+	6,  // 16: reddit.GetPostResponse.post:type_name -> reddit.Post
+	7,  // 17: reddit.CreateCommentRequest.comment:type_name -> reddit.Comment
+	7,  // 18: reddit.CreateCommentResponse.comment:type_name -> reddit.Comment
+	7,  // 19: reddit.GetCommentResponse.comment:type_name -> reddit.Comment
+	23, // 20: reddit.BatchGetPostsResponse.results:type_name -> reddit.PostResult
+	6,  // 21: reddit.PostResult.post:type_name -> reddit.Post
+	26, // 22: reddit.BatchGetCommentsResponse.results:type_name -> reddit.CommentResult
+	7,  // 23: reddit.CommentResult.comment:type_name -> reddit.Comment
+	7,  // 24: reddit.GetTopCommentsResponse.comments:type_name -> reddit.Comment
+	7,  // 25: reddit.ExpandCommentBranchResponse.comments:type_name -> reddit.Comment
+	3,  // 26: reddit.MonitorUpdatesRequest.contentType:type_name -> reddit.ContentType
+	3,  // 27: reddit.MonitorUpdatesResponse.contentType:type_name -> reddit.ContentType
+	31, // 28: reddit.WatchUpdatesRequest.subscriptions:type_name -> reddit.MonitorUpdatesRequest
+	6,  // 29: reddit.ListPostsResponse.posts:type_name -> reddit.Post
+	6,  // 30: reddit.SearchPostsResponse.posts:type_name -> reddit.Post
+	5,  // 31: reddit.GetSubRedditResponse.subReddit:type_name -> reddit.SubReddit
+	5,  // 32: reddit.ListSubRedditsResponse.subReddits:type_name -> reddit.SubReddit
+	9,  // 33: reddit.Reddit.CreatePost:input_type -> reddit.CreatePostRequest
+	11, // 34: reddit.Reddit.VotePost:input_type -> reddit.VotePostRequest
+	13, // 35: reddit.Reddit.GetPost:input_type -> reddit.GetPostRequest
+	15, // 36: reddit.Reddit.CreateComment:input_type -> reddit.CreateCommentRequest
+	17, // 37: reddit.Reddit.VoteComment:input_type -> reddit.VoteCommentRequest
+	19, // 38: reddit.Reddit.GetComment:input_type -> reddit.GetCommentRequest
+	21, // 39: reddit.Reddit.BatchGetPosts:input_type -> reddit.BatchGetPostsRequest
+	24, // 40: reddit.Reddit.BatchGetComments:input_type -> reddit.BatchGetCommentsRequest
+	27, // 41: reddit.Reddit.GetTopComments:input_type -> reddit.GetTopCommentsRequest
+	29, // 42: reddit.Reddit.ExpandCommentBranch:input_type -> reddit.ExpandCommentBranchRequest
+	31, // 43: reddit.Reddit.MonitorUpdates:input_type -> reddit.MonitorUpdatesRequest
+	33, // 44: reddit.Reddit.WatchUpdates:input_type -> reddit.WatchUpdatesRequest
+	34, // 45: reddit.Reddit.ListPosts:input_type -> reddit.ListPostsRequest
+	36, // 46: reddit.Reddit.SearchPosts:input_type -> reddit.SearchPostsRequest
+	38, // 47: reddit.Reddit.GetSubReddit:input_type -> reddit.GetSubRedditRequest
+	40, // 48: reddit.Reddit.ListSubReddits:input_type -> reddit.ListSubRedditsRequest
+	10, // 49: reddit.Reddit.CreatePost:output_type -> reddit.CreatePostResponse
+	12, // 50: reddit.Reddit.VotePost:output_type -> reddit.VotePostResponse
+	14, // 51: reddit.Reddit.GetPost:output_type -> reddit.GetPostResponse
+	16, // 52: reddit.Reddit.CreateComment:output_type -> reddit.CreateCommentResponse
+	18, // 53: reddit.Reddit.VoteComment:output_type -> reddit.VoteCommentResponse
+	20, // 54: reddit.Reddit.GetComment:output_type -> reddit.GetCommentResponse
+	22, // 55: reddit.Reddit.BatchGetPosts:output_type -> reddit.BatchGetPostsResponse
+	25, // 56: reddit.Reddit.BatchGetComments:output_type -> reddit.BatchGetCommentsResponse
+	28, // 57: reddit.Reddit.GetTopComments:output_type -> reddit.GetTopCommentsResponse
+	30, // 58: reddit.Reddit.ExpandCommentBranch:output_type -> reddit.ExpandCommentBranchResponse
+	32, // 59: reddit.Reddit.MonitorUpdates:output_type -> reddit.MonitorUpdatesResponse
+	32, // 60: reddit.Reddit.WatchUpdates:output_type -> reddit.MonitorUpdatesResponse
+	35, // 61: reddit.Reddit.ListPosts:output_type -> reddit.ListPostsResponse
+	37, // 62: reddit.Reddit.SearchPosts:output_type -> reddit.SearchPostsResponse
+	39, // 63: reddit.Reddit.GetSubReddit:output_type -> reddit.GetSubRedditResponse
+	41, // 64: reddit.Reddit.ListSubReddits:output_type -> reddit.ListSubRedditsResponse
+	49, // [49:65] is the sub-list for method output_type
+	33, // [33:49] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_reddit_reddit_proto_init() }
@@ -1863,7 +2971,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreatePostRequest); i {
+			switch v := v.(*ExportRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1875,7 +2983,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreatePostResponse); i {
+			switch v := v.(*CreatePostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1887,7 +2995,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VotePostRequest); i {
+			switch v := v.(*CreatePostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1899,7 +3007,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VotePostResponse); i {
+			switch v := v.(*VotePostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1911,7 +3019,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPostRequest); i {
+			switch v := v.(*VotePostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1923,7 +3031,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPostResponse); i {
+			switch v := v.(*GetPostRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1935,7 +3043,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateCommentRequest); i {
+			switch v := v.(*GetPostResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1947,7 +3055,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateCommentResponse); i {
+			switch v := v.(*CreateCommentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1959,7 +3067,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VoteCommentRequest); i {
+			switch v := v.(*CreateCommentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1971,7 +3079,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VoteCommentResponse); i {
+			switch v := v.(*VoteCommentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1983,7 +3091,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCommentRequest); i {
+			switch v := v.(*VoteCommentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1995,7 +3103,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCommentResponse); i {
+			switch v := v.(*GetCommentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2007,7 +3115,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTopCommentsRequest); i {
+			switch v := v.(*GetCommentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2019,7 +3127,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTopCommentsResponse); i {
+			switch v := v.(*BatchGetPostsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2031,7 +3139,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpandCommentBranchRequest); i {
+			switch v := v.(*BatchGetPostsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2043,7 +3151,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpandCommentBranchResponse); i {
+			switch v := v.(*PostResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2055,7 +3163,7 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonitorUpdatesRequest); i {
+			switch v := v.(*BatchGetCommentsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2067,6 +3175,90 @@ func file_reddit_reddit_proto_init() {
 			}
 		}
 		file_reddit_reddit_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetCommentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommentResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTopCommentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTopCommentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpandCommentBranchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpandCommentBranchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonitorUpdatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MonitorUpdatesResponse); i {
 			case 0:
 				return &v.state
@@ -2078,15 +3270,129 @@ func file_reddit_reddit_proto_init() {
 				return nil
 			}
 		}
+		file_reddit_reddit_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchUpdatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubRedditRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubRedditResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSubRedditsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_reddit_reddit_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSubRedditsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_reddit_reddit_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_reddit_reddit_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*ExportRecord_User)(nil),
+		(*ExportRecord_SubReddit)(nil),
+		(*ExportRecord_Post)(nil),
+		(*ExportRecord_Comment)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_reddit_reddit_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   22,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -34,12 +34,27 @@ type RedditClient interface {
 	VoteComment(ctx context.Context, in *VoteCommentRequest, opts ...grpc.CallOption) (*VoteCommentResponse, error)
 	// Retrieve a Comment
 	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*GetCommentResponse, error)
+	// Retrieve several Posts at once
+	BatchGetPosts(ctx context.Context, in *BatchGetPostsRequest, opts ...grpc.CallOption) (*BatchGetPostsResponse, error)
+	// Retrieve several Comments at once
+	BatchGetComments(ctx context.Context, in *BatchGetCommentsRequest, opts ...grpc.CallOption) (*BatchGetCommentsResponse, error)
 	// Retrieving a list of N most upvoted comments under a post
 	GetTopComments(ctx context.Context, in *GetTopCommentsRequest, opts ...grpc.CallOption) (*GetTopCommentsResponse, error)
 	// Expand a comment branch
 	ExpandCommentBranch(ctx context.Context, in *ExpandCommentBranchRequest, opts ...grpc.CallOption) (*ExpandCommentBranchResponse, error)
 	// Monitor updates to posts and comments
 	MonitorUpdates(ctx context.Context, opts ...grpc.CallOption) (Reddit_MonitorUpdatesClient, error)
+	// Watch updates to a fixed set of posts and comments, for clients without
+	// bidirectional streaming such as gRPC-Web
+	WatchUpdates(ctx context.Context, in *WatchUpdatesRequest, opts ...grpc.CallOption) (Reddit_WatchUpdatesClient, error)
+	// List the posts of a subreddit, or of every subreddit
+	ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
+	// Search posts by title and content
+	SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error)
+	// Retrieve a SubReddit
+	GetSubReddit(ctx context.Context, in *GetSubRedditRequest, opts ...grpc.CallOption) (*GetSubRedditResponse, error)
+	// List the subreddits that are not hidden
+	ListSubReddits(ctx context.Context, in *ListSubRedditsRequest, opts ...grpc.CallOption) (*ListSubRedditsResponse, error)
 }
 
 type redditClient struct {
@@ -104,6 +119,24 @@ func (c *redditClient) GetComment(ctx context.Context, in *GetCommentRequest, op
 	return out, nil
 }
 
+func (c *redditClient) BatchGetPosts(ctx context.Context, in *BatchGetPostsRequest, opts ...grpc.CallOption) (*BatchGetPostsResponse, error) {
+	out := new(BatchGetPostsResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/BatchGetPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) BatchGetComments(ctx context.Context, in *BatchGetCommentsRequest, opts ...grpc.CallOption) (*BatchGetCommentsResponse, error) {
+	out := new(BatchGetCommentsResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/BatchGetComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *redditClient) GetTopComments(ctx context.Context, in *GetTopCommentsRequest, opts ...grpc.CallOption) (*GetTopCommentsResponse, error) {
 	out := new(GetTopCommentsResponse)
 	err := c.cc.Invoke(ctx, "/reddit.Reddit/GetTopComments", in, out, opts...)
@@ -153,6 +186,74 @@ func (x *redditMonitorUpdatesClient) Recv() (*MonitorUpdatesResponse, error) {
 	return m, nil
 }
 
+func (c *redditClient) WatchUpdates(ctx context.Context, in *WatchUpdatesRequest, opts ...grpc.CallOption) (Reddit_WatchUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Reddit_ServiceDesc.Streams[1], "/reddit.Reddit/WatchUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &redditWatchUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Reddit_WatchUpdatesClient interface {
+	Recv() (*MonitorUpdatesResponse, error)
+	grpc.ClientStream
+}
+
+type redditWatchUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *redditWatchUpdatesClient) Recv() (*MonitorUpdatesResponse, error) {
+	m := new(MonitorUpdatesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *redditClient) ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error) {
+	out := new(ListPostsResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/ListPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) SearchPosts(ctx context.Context, in *SearchPostsRequest, opts ...grpc.CallOption) (*SearchPostsResponse, error) {
+	out := new(SearchPostsResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/SearchPosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) GetSubReddit(ctx context.Context, in *GetSubRedditRequest, opts ...grpc.CallOption) (*GetSubRedditResponse, error) {
+	out := new(GetSubRedditResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/GetSubReddit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) ListSubReddits(ctx context.Context, in *ListSubRedditsRequest, opts ...grpc.CallOption) (*ListSubRedditsResponse, error) {
+	out := new(ListSubRedditsResponse)
+	err := c.cc.Invoke(ctx, "/reddit.Reddit/ListSubReddits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RedditServer is the server API for Reddit service.
 // All implementations must embed UnimplementedRedditServer
 // for forward compatibility
@@ -169,12 +270,27 @@ type RedditServer interface {
 	VoteComment(context.Context, *VoteCommentRequest) (*VoteCommentResponse, error)
 	// Retrieve a Comment
 	GetComment(context.Context, *GetCommentRequest) (*GetCommentResponse, error)
+	// Retrieve several Posts at once
+	BatchGetPosts(context.Context, *BatchGetPostsRequest) (*BatchGetPostsResponse, error)
+	// Retrieve several Comments at once
+	BatchGetComments(context.Context, *BatchGetCommentsRequest) (*BatchGetCommentsResponse, error)
 	// Retrieving a list of N most upvoted comments under a post
 	GetTopComments(context.Context, *GetTopCommentsRequest) (*GetTopCommentsResponse, error)
 	// Expand a comment branch
 	ExpandCommentBranch(context.Context, *ExpandCommentBranchRequest) (*ExpandCommentBranchResponse, error)
 	// Monitor updates to posts and comments
 	MonitorUpdates(Reddit_MonitorUpdatesServer) error
+	// Watch updates to a fixed set of posts and comments, for clients without
+	// bidirectional streaming such as gRPC-Web
+	WatchUpdates(*WatchUpdatesRequest, Reddit_WatchUpdatesServer) error
+	// List the posts of a subreddit, or of every subreddit
+	ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error)
+	// Search posts by title and content
+	SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error)
+	// Retrieve a SubReddit
+	GetSubReddit(context.Context, *GetSubRedditRequest) (*GetSubRedditResponse, error)
+	// List the subreddits that are not hidden
+	ListSubReddits(context.Context, *ListSubRedditsRequest) (*ListSubRedditsResponse, error)
 	mustEmbedUnimplementedRedditServer()
 }
 
@@ -200,6 +316,12 @@ func (UnimplementedRedditServer) VoteComment(context.Context, *VoteCommentReques
 func (UnimplementedRedditServer) GetComment(context.Context, *GetCommentRequest) (*GetCommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetComment not implemented")
 }
+func (UnimplementedRedditServer) BatchGetPosts(context.Context, *BatchGetPostsRequest) (*BatchGetPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetPosts not implemented")
+}
+func (UnimplementedRedditServer) BatchGetComments(context.Context, *BatchGetCommentsRequest) (*BatchGetCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetComments not implemented")
+}
 func (UnimplementedRedditServer) GetTopComments(context.Context, *GetTopCommentsRequest) (*GetTopCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopComments not implemented")
 }
@@ -209,6 +331,21 @@ func (UnimplementedRedditServer) ExpandCommentBranch(context.Context, *ExpandCom
 func (UnimplementedRedditServer) MonitorUpdates(Reddit_MonitorUpdatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method MonitorUpdates not implemented")
 }
+func (UnimplementedRedditServer) WatchUpdates(*WatchUpdatesRequest, Reddit_WatchUpdatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUpdates not implemented")
+}
+func (UnimplementedRedditServer) ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPosts not implemented")
+}
+func (UnimplementedRedditServer) SearchPosts(context.Context, *SearchPostsRequest) (*SearchPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPosts not implemented")
+}
+func (UnimplementedRedditServer) GetSubReddit(context.Context, *GetSubRedditRequest) (*GetSubRedditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubReddit not implemented")
+}
+func (UnimplementedRedditServer) ListSubReddits(context.Context, *ListSubRedditsRequest) (*ListSubRedditsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubReddits not implemented")
+}
 func (UnimplementedRedditServer) mustEmbedUnimplementedRedditServer() {}
 
 // UnsafeRedditServer may be embedded to opt out of forward compatibility for this service.
@@ -330,6 +467,42 @@ func _Reddit_GetComment_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Reddit_BatchGetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).BatchGetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/BatchGetPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).BatchGetPosts(ctx, req.(*BatchGetPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_BatchGetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).BatchGetComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/BatchGetComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).BatchGetComments(ctx, req.(*BatchGetCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Reddit_GetTopComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTopCommentsRequest)
 	if err := dec(in); err != nil {
@@ -392,6 +565,99 @@ func (x *redditMonitorUpdatesServer) Recv() (*MonitorUpdatesRequest, error) {
 	return m, nil
 }
 
+func _Reddit_WatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RedditServer).WatchUpdates(m, &redditWatchUpdatesServer{stream})
+}
+
+type Reddit_WatchUpdatesServer interface {
+	Send(*MonitorUpdatesResponse) error
+	grpc.ServerStream
+}
+
+type redditWatchUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *redditWatchUpdatesServer) Send(m *MonitorUpdatesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Reddit_ListPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).ListPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/ListPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).ListPosts(ctx, req.(*ListPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_SearchPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).SearchPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/SearchPosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).SearchPosts(ctx, req.(*SearchPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_GetSubReddit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubRedditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).GetSubReddit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/GetSubReddit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).GetSubReddit(ctx, req.(*GetSubRedditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_ListSubReddits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubRedditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).ListSubReddits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reddit.Reddit/ListSubReddits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).ListSubReddits(ctx, req.(*ListSubRedditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Reddit_ServiceDesc is the grpc.ServiceDesc for Reddit service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -423,6 +689,14 @@ var Reddit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetComment",
 			Handler:    _Reddit_GetComment_Handler,
 		},
+		{
+			MethodName: "BatchGetPosts",
+			Handler:    _Reddit_BatchGetPosts_Handler,
+		},
+		{
+			MethodName: "BatchGetComments",
+			Handler:    _Reddit_BatchGetComments_Handler,
+		},
 		{
 			MethodName: "GetTopComments",
 			Handler:    _Reddit_GetTopComments_Handler,
@@ -431,6 +705,22 @@ var Reddit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExpandCommentBranch",
 			Handler:    _Reddit_ExpandCommentBranch_Handler,
 		},
+		{
+			MethodName: "ListPosts",
+			Handler:    _Reddit_ListPosts_Handler,
+		},
+		{
+			MethodName: "SearchPosts",
+			Handler:    _Reddit_SearchPosts_Handler,
+		},
+		{
+			MethodName: "GetSubReddit",
+			Handler:    _Reddit_GetSubReddit_Handler,
+		},
+		{
+			MethodName: "ListSubReddits",
+			Handler:    _Reddit_ListSubReddits_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -439,6 +729,11 @@ var Reddit_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchUpdates",
+			Handler:       _Reddit_WatchUpdates_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "reddit/reddit.proto",
 }
@@ -0,0 +1,336 @@
+// Package redditclient is a Go client for the Reddit gRPC service.
+package redditclient
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type RedditUser = pb.User
+type RedditSubReddit = pb.SubReddit
+type RedditPost = pb.Post
+type RedditComment = pb.Comment
+type PostResult = pb.PostResult
+type CommentResult = pb.CommentResult
+type ContentType = pb.ContentType
+type UpdateRequest = pb.MonitorUpdatesRequest
+type Update = pb.MonitorUpdatesResponse
+
+// Types of content updates are monitored for
+const (
+	ContentPost    = pb.ContentType_POST
+	ContentComment = pb.ContentType_COMMENT
+)
+
+// Bidirectional stream of MonitorUpdates, subscribing with Send and
+// receiving scores with Recv
+type MonitorStream = pb.Reddit_MonitorUpdatesClient
+
+// Server stream of WatchUpdates, receiving scores with Recv
+type WatchStream = pb.Reddit_WatchUpdatesClient
+
+// Interface for the Reddit API
+type RedditAPI interface {
+	CreatePost(ctx context.Context, title string, content string, subRedditID int32, authorID int32, opts ...PostOption) (*RedditPost, error)
+	VotePost(ctx context.Context, postID int32, upvote bool, opts ...VoteOption) (int32, error)
+	GetPost(ctx context.Context, postID int32) (*RedditPost, error)
+	CreateComment(ctx context.Context, comment *RedditComment) (*RedditComment, error)
+	ReplyToPost(ctx context.Context, postID int32, authorID int32, content string) (*RedditComment, error)
+	ReplyToComment(ctx context.Context, commentID int32, authorID int32, content string) (*RedditComment, error)
+	VoteComment(ctx context.Context, commentID int32, upvote bool, opts ...VoteOption) (int32, error)
+	GetComment(ctx context.Context, commentID int32) (*RedditComment, error)
+	BatchGetPosts(ctx context.Context, postIDs []int32) ([]*PostResult, error)
+	BatchGetComments(ctx context.Context, commentIDs []int32) ([]*CommentResult, error)
+	GetTopComments(ctx context.Context, postID int32, quantity int32) ([]*RedditComment, error)
+	ExpandCommentBranch(ctx context.Context, commentID int32, quantity int32) ([]*RedditComment, error)
+	ListPosts(ctx context.Context, subRedditID int32, pageSize int32, pageToken string) ([]*RedditPost, string, error)
+	SearchPosts(ctx context.Context, query string, subRedditID int32, limit int32) ([]*RedditPost, error)
+	GetSubReddit(ctx context.Context, subRedditID int32) (*RedditSubReddit, error)
+	ListSubReddits(ctx context.Context, pageSize int32, pageToken string) ([]*RedditSubReddit, string, error)
+	MonitorUpdates(ctx context.Context) (MonitorStream, error)
+	WatchUpdates(ctx context.Context, requests ...*UpdateRequest) (WatchStream, error)
+	Subscribe(ctx context.Context, opts ...SubscriptionOption) *Subscription
+}
+
+var _ RedditAPI = (*RedditAPIClient)(nil)
+
+// Implementation of the wrapper client
+type RedditAPIClient struct {
+	_client pb.RedditClient
+	_conn   *grpc.ClientConn
+}
+
+// Constructor, connects to DefaultAddress with insecure credentials unless
+// opts override them. Reads are retried with DefaultRetryPolicy, and calls
+// are traced with the global OpenTelemetry tracer provider.
+func NewRedditAPIClient(opts ...Option) (*RedditAPIClient, error) {
+	o := &options{
+		address:      DefaultAddress,
+		creds:        insecure.NewCredentials(),
+		retry:        DefaultRetryPolicy,
+		payloadLimit: defaultPayloadLimit,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	unary := []grpc.UnaryClientInterceptor{retryInterceptor(o.retry, o.hedging)}
+	stream := []grpc.StreamClientInterceptor{}
+	if o.timeout > 0 {
+		unary = append(unary, timeoutInterceptor(o.timeout))
+	}
+	if o.logger != nil {
+		unary = append(unary, unaryLoggingInterceptor(o.logger, o.payloadLimit))
+		stream = append(stream, streamLoggingInterceptor(o.logger, o.payloadLimit))
+	}
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(append(unary, o.unaryInterceptors...)...),
+		grpc.WithChainStreamInterceptor(append(stream, o.streamInterceptors...)...),
+	}, o.dialOptions...)
+	if o.token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(tokenCredentials(o.token)))
+	}
+
+	// Set up a connection to the server.
+	conn, err := grpc.Dial(o.address, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", o.address, err)
+	}
+
+	return &RedditAPIClient{
+		_client: pb.NewRedditClient(conn),
+		_conn:   conn,
+	}, nil
+}
+
+// Close the connection
+func (s *RedditAPIClient) Close() error {
+	return s._conn.Close()
+}
+
+// Conn returns the underlying connection, for services and call options
+// the client does not wrap
+func (s *RedditAPIClient) Conn() *grpc.ClientConn {
+	return s._conn
+}
+
+/**
+ *
+ * Wrapper functions for the Reddit API
+ *
+ */
+
+// Create a post, with media URLs set by opts
+func (s *RedditAPIClient) CreatePost(ctx context.Context, title string, content string, subRedditID int32, authorID int32, opts ...PostOption) (*RedditPost, error) {
+	post := &RedditPost{
+		Title:     title,
+		Content:   content,
+		SubReddit: &RedditSubReddit{Id: subRedditID},
+		Author:    &RedditUser{Id: authorID},
+	}
+	for _, opt := range opts {
+		opt(post)
+	}
+	request := &pb.CreatePostRequest{Post: post}
+
+	response, err := s._client.CreatePost(ctx, request)
+	if err != nil {
+		return nil, newError("CreatePost", err)
+	}
+
+	return response.Post, nil
+}
+
+// Upvote or downvote a Post
+func (s *RedditAPIClient) VotePost(ctx context.Context, postID int32, upvote bool, opts ...VoteOption) (int32, error) {
+	request := &pb.VotePostRequest{PostID: postID, VoterID: newVoteOptions(opts).voterID, Upvote: upvote}
+
+	response, err := s._client.VotePost(ctx, request)
+	if err != nil {
+		return -1, newError("VotePost", err)
+	}
+
+	return response.Score, nil
+}
+
+// Retrieve Post content
+func (s *RedditAPIClient) GetPost(ctx context.Context, postID int32) (*RedditPost, error) {
+	request := &pb.GetPostRequest{PostID: postID}
+
+	response, err := s._client.GetPost(ctx, request)
+	if err != nil {
+		return nil, newError("GetPost", err)
+	}
+	return response.Post, nil
+}
+
+// Create a Comment under the post or comment its Parent and ParentID name
+func (s *RedditAPIClient) CreateComment(ctx context.Context, comment *RedditComment) (*RedditComment, error) {
+	request := &pb.CreateCommentRequest{Comment: comment}
+
+	response, err := s._client.CreateComment(ctx, request)
+	if err != nil {
+		return nil, newError("CreateComment", err)
+	}
+	return response.Comment, nil
+}
+
+// Comment on a post
+func (s *RedditAPIClient) ReplyToPost(ctx context.Context, postID int32, authorID int32, content string) (*RedditComment, error) {
+	return s.CreateComment(ctx, &RedditComment{
+		Content:  content,
+		Author:   &RedditUser{Id: authorID},
+		Parent:   pb.ContentType_POST,
+		ParentID: postID,
+	})
+}
+
+// Reply to a comment
+func (s *RedditAPIClient) ReplyToComment(ctx context.Context, commentID int32, authorID int32, content string) (*RedditComment, error) {
+	return s.CreateComment(ctx, &RedditComment{
+		Content:  content,
+		Author:   &RedditUser{Id: authorID},
+		Parent:   pb.ContentType_COMMENT,
+		ParentID: commentID,
+	})
+}
+
+// Upvote or downvote a Comment
+func (s *RedditAPIClient) VoteComment(ctx context.Context, commentID int32, upvote bool, opts ...VoteOption) (int32, error) {
+	request := &pb.VoteCommentRequest{CommentID: commentID, VoterID: newVoteOptions(opts).voterID, Upvote: upvote}
+
+	response, err := s._client.VoteComment(ctx, request)
+	if err != nil {
+		return -1, newError("VoteComment", err)
+	}
+	return response.Score, nil
+}
+
+// Retrieve a Comment
+func (s *RedditAPIClient) GetComment(ctx context.Context, commentID int32) (*RedditComment, error) {
+	request := &pb.GetCommentRequest{CommentID: commentID}
+
+	response, err := s._client.GetComment(ctx, request)
+	if err != nil {
+		return nil, newError("GetComment", err)
+	}
+	return response.Comment, nil
+}
+
+// Retrieve up to 100 Posts at once, with a result for each ID in order,
+// marked NotFound when there is no post of the ID
+func (s *RedditAPIClient) BatchGetPosts(ctx context.Context, postIDs []int32) ([]*PostResult, error) {
+	request := &pb.BatchGetPostsRequest{PostIDs: postIDs}
+
+	response, err := s._client.BatchGetPosts(ctx, request)
+	if err != nil {
+		return nil, newError("BatchGetPosts", err)
+	}
+	return response.Results, nil
+}
+
+// Retrieve up to 100 Comments at once, with a result for each ID in order,
+// marked NotFound when there is no comment of the ID
+func (s *RedditAPIClient) BatchGetComments(ctx context.Context, commentIDs []int32) ([]*CommentResult, error) {
+	request := &pb.BatchGetCommentsRequest{CommentIDs: commentIDs}
+
+	response, err := s._client.BatchGetComments(ctx, request)
+	if err != nil {
+		return nil, newError("BatchGetComments", err)
+	}
+	return response.Results, nil
+}
+
+// Retrieving a list of N most upvoted comments under a post
+func (s *RedditAPIClient) GetTopComments(ctx context.Context, postID int32, quantity int32) ([]*RedditComment, error) {
+	requests := &pb.GetTopCommentsRequest{PostID: postID, Quantity: quantity}
+
+	response, err := s._client.GetTopComments(ctx, requests)
+	if err != nil {
+		return nil, newError("GetTopComments", err)
+	}
+	return response.Comments, nil
+}
+
+// Expand a comment branch
+func (s *RedditAPIClient) ExpandCommentBranch(ctx context.Context, commentID int32, quantity int32) ([]*RedditComment, error) {
+	requests := &pb.ExpandCommentBranchRequest{CommentID: commentID, Quantity: quantity}
+
+	response, err := s._client.ExpandCommentBranch(ctx, requests)
+	if err != nil {
+		return nil, newError("ExpandCommentBranch", err)
+	}
+	return response.Comments, nil
+}
+
+// List the posts of a subreddit, or of every subreddit when subRedditID is
+// 0. The returned page token, empty on the last page, fetches the next page.
+func (s *RedditAPIClient) ListPosts(ctx context.Context, subRedditID int32, pageSize int32, pageToken string) ([]*RedditPost, string, error) {
+	request := &pb.ListPostsRequest{SubRedditID: subRedditID, PageSize: pageSize, PageToken: pageToken}
+
+	response, err := s._client.ListPosts(ctx, request)
+	if err != nil {
+		return nil, "", newError("ListPosts", err)
+	}
+	return response.Posts, response.NextPageToken, nil
+}
+
+// Search posts by title and content, in a subreddit or in every subreddit
+// when subRedditID is 0
+func (s *RedditAPIClient) SearchPosts(ctx context.Context, query string, subRedditID int32, limit int32) ([]*RedditPost, error) {
+	request := &pb.SearchPostsRequest{Query: query, SubRedditID: subRedditID, Limit: limit}
+
+	response, err := s._client.SearchPosts(ctx, request)
+	if err != nil {
+		return nil, newError("SearchPosts", err)
+	}
+	return response.Posts, nil
+}
+
+// Retrieve a SubReddit
+func (s *RedditAPIClient) GetSubReddit(ctx context.Context, subRedditID int32) (*RedditSubReddit, error) {
+	request := &pb.GetSubRedditRequest{SubRedditID: subRedditID}
+
+	response, err := s._client.GetSubReddit(ctx, request)
+	if err != nil {
+		return nil, newError("GetSubReddit", err)
+	}
+	return response.SubReddit, nil
+}
+
+// List the subreddits that are not hidden. The returned page token, empty
+// on the last page, fetches the next page.
+func (s *RedditAPIClient) ListSubReddits(ctx context.Context, pageSize int32, pageToken string) ([]*RedditSubReddit, string, error) {
+	request := &pb.ListSubRedditsRequest{PageSize: pageSize, PageToken: pageToken}
+
+	response, err := s._client.ListSubReddits(ctx, request)
+	if err != nil {
+		return nil, "", newError("ListSubReddits", err)
+	}
+	return response.SubReddits, response.NextPageToken, nil
+}
+
+// Monitor updates to posts and comments
+func (s *RedditAPIClient) MonitorUpdates(ctx context.Context) (MonitorStream, error) {
+	stream, err := s._client.MonitorUpdates(ctx)
+	if err != nil {
+		return nil, newError("MonitorUpdates", err)
+	}
+	return stream, nil
+}
+
+// Watch updates to a fixed set of posts and comments
+func (s *RedditAPIClient) WatchUpdates(ctx context.Context, requests ...*UpdateRequest) (WatchStream, error) {
+	stream, err := s._client.WatchUpdates(ctx, &pb.WatchUpdatesRequest{Subscriptions: requests})
+	if err != nil {
+		return nil, newError("WatchUpdates", err)
+	}
+	return stream, nil
+}
@@ -0,0 +1,145 @@
+package redditclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Reddit server failing every call with NotFound
+type notFoundServer struct {
+	pb.UnimplementedRedditServer
+}
+
+func (notFoundServer) GetPost(context.Context, *pb.GetPostRequest) (*pb.GetPostResponse, error) {
+	return nil, status.Error(codes.NotFound, "post not found")
+}
+
+// Block until the call is done
+func (notFoundServer) GetComment(ctx context.Context, _ *pb.GetCommentRequest) (*pb.GetCommentResponse, error) {
+	<-ctx.Done()
+	return nil, status.FromContextError(ctx.Err()).Err()
+}
+
+// Connect a client to srv served in process
+func newTestClient(t *testing.T, srv pb.RedditServer, opts ...Option) *RedditAPIClient {
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	pb.RegisterRedditServer(gs, srv)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	opts = append(opts, WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})))
+	s, err := NewRedditAPIClient(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestClientErrors(t *testing.T) {
+	s := newTestClient(t, notFoundServer{})
+
+	_, err := s.GetPost(context.Background(), 1)
+	var clientErr *Error
+	require.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, "GetPost", clientErr.Op)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "GetPost: NotFound: post not found", err.Error())
+
+	// Calls fail instead of exiting when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.GetComment(ctx, 1)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestClientOptions(t *testing.T) {
+	called := []string{}
+	s := newTestClient(t, notFoundServer{},
+		WithTimeout(50*time.Millisecond),
+		WithRetry(RetryPolicy{}),
+		WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			called = append(called, method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+
+	_, err := s.GetComment(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrDeadlineExceeded)
+	assert.Equal(t, []string{"/reddit.Reddit/GetComment"}, called)
+}
+
+// Reddit server recording the requests that create and vote
+type recordingServer struct {
+	pb.UnimplementedRedditServer
+	requests []any
+}
+
+func (s *recordingServer) CreatePost(_ context.Context, req *pb.CreatePostRequest) (*pb.CreatePostResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.CreatePostResponse{Post: req.GetPost()}, nil
+}
+
+func (s *recordingServer) CreateComment(_ context.Context, req *pb.CreateCommentRequest) (*pb.CreateCommentResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.CreateCommentResponse{Comment: req.GetComment()}, nil
+}
+
+func (s *recordingServer) VotePost(_ context.Context, req *pb.VotePostRequest) (*pb.VotePostResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.VotePostResponse{Score: 1}, nil
+}
+
+func (s *recordingServer) VoteComment(_ context.Context, req *pb.VoteCommentRequest) (*pb.VoteCommentResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.VoteCommentResponse{Score: -1}, nil
+}
+
+func TestClientRequests(t *testing.T) {
+	srv := &recordingServer{}
+	s := newTestClient(t, srv)
+	ctx := context.Background()
+
+	_, err := s.CreatePost(ctx, "Cat", "Meow", 1, 2, WithVideoURL("https://example.com/cat.mp4"), WithImageURL("https://example.com/cat.png"))
+	require.NoError(t, err)
+	_, err = s.ReplyToPost(ctx, 3, 2, "Cute")
+	require.NoError(t, err)
+	_, err = s.ReplyToComment(ctx, 4, 2, "Agreed")
+	require.NoError(t, err)
+	_, err = s.VotePost(ctx, 3, true, WithVoter(2))
+	require.NoError(t, err)
+	score, err := s.VoteComment(ctx, 4, false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), score)
+
+	require.Len(t, srv.requests, 5)
+	post := srv.requests[0].(*pb.CreatePostRequest).GetPost()
+	assert.Equal(t, "https://example.com/cat.mp4", post.GetVideoURL())
+	assert.Equal(t, "https://example.com/cat.png", post.GetImageURL())
+	assert.Equal(t, int32(2), post.GetAuthor().GetId())
+
+	reply := srv.requests[1].(*pb.CreateCommentRequest).GetComment()
+	assert.Equal(t, pb.ContentType_POST, reply.GetParent())
+	assert.Equal(t, int32(3), reply.GetParentID())
+	reply = srv.requests[2].(*pb.CreateCommentRequest).GetComment()
+	assert.Equal(t, pb.ContentType_COMMENT, reply.GetParent())
+	assert.Equal(t, int32(4), reply.GetParentID())
+
+	assert.Equal(t, int32(2), srv.requests[3].(*pb.VotePostRequest).GetVoterID())
+	assert.Equal(t, int32(0), srv.requests[4].(*pb.VoteCommentRequest).GetVoterID())
+}
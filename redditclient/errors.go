@@ -0,0 +1,54 @@
+package redditclient
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error returned by the Reddit API client, wrapping the gRPC status of the
+// failed call. status.Code and status.FromError see through it, and
+// errors.Is matches it against the sentinel errors by status code.
+type Error struct {
+	Op   string // The RPC that failed
+	Code codes.Code
+	Err  error
+}
+
+// Sentinel errors for the status codes callers usually handle
+var (
+	ErrInvalidArgument   = &Error{Code: codes.InvalidArgument}
+	ErrNotFound          = &Error{Code: codes.NotFound}
+	ErrResourceExhausted = &Error{Code: codes.ResourceExhausted}
+	ErrUnavailable       = &Error{Code: codes.Unavailable}
+	ErrDeadlineExceeded  = &Error{Code: codes.DeadlineExceeded}
+)
+
+func newError(op string, err error) error {
+	return &Error{Op: op, Code: status.Code(err), Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Code.String()
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Op, e.Code, status.Convert(e.Err).Message())
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+	if e.Err == nil {
+		return status.New(e.Code, e.Code.String())
+	}
+	return status.Convert(e.Err)
+}
+
+// Match sentinel errors, which carry no operation, by status code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Op == "" && t.Code == e.Code
+}
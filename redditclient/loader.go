@@ -0,0 +1,230 @@
+package redditclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultLoaderWait    = time.Millisecond
+	DefaultLoaderTimeout = 10 * time.Second
+	maxBatchSize         = 100
+)
+
+// Loader gathers the posts and comments loaded concurrently, such as those
+// of a thread view rendered by many goroutines, into BatchGetPosts and
+// BatchGetComments calls. Each ID is fetched once for the lifetime of the
+// loader, so a loader is meant to serve one view or request.
+type Loader struct {
+	posts    *batcher[*RedditPost]
+	comments *batcher[*RedditComment]
+}
+
+// LoaderOption configures a Loader
+type LoaderOption func(*loaderOptions)
+
+type loaderOptions struct {
+	wait     time.Duration
+	timeout  time.Duration
+	maxBatch int
+}
+
+// WithLoaderWait sets how long a batch waits for more IDs after its first,
+// DefaultLoaderWait by default
+func WithLoaderWait(wait time.Duration) LoaderOption {
+	return func(o *loaderOptions) { o.wait = wait }
+}
+
+// WithLoaderTimeout sets the deadline of each batch call,
+// DefaultLoaderTimeout by default. Callers stop waiting at their own
+// deadline, but the call they joined goes on for the others until this one.
+func WithLoaderTimeout(timeout time.Duration) LoaderOption {
+	return func(o *loaderOptions) { o.timeout = timeout }
+}
+
+// WithMaxBatch sets the most IDs of a batch, sent at once when reached,
+// 100 by default which is also the most the server accepts
+func WithMaxBatch(n int) LoaderOption {
+	return func(o *loaderOptions) { o.maxBatch = min(max(n, 1), maxBatchSize) }
+}
+
+func NewLoader(api RedditAPI, opts ...LoaderOption) *Loader {
+	o := &loaderOptions{wait: DefaultLoaderWait, timeout: DefaultLoaderTimeout, maxBatch: maxBatchSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Loader{
+		posts: newBatcher(o, "BatchGetPosts", "post", func(ctx context.Context, ids []int32) (map[int32]*RedditPost, error) {
+			results, err := api.BatchGetPosts(ctx, ids)
+			found := make(map[int32]*RedditPost, len(results))
+			for _, result := range results {
+				if !result.GetNotFound() {
+					found[result.GetPostID()] = result.GetPost()
+				}
+			}
+			return found, err
+		}),
+		comments: newBatcher(o, "BatchGetComments", "comment", func(ctx context.Context, ids []int32) (map[int32]*RedditComment, error) {
+			results, err := api.BatchGetComments(ctx, ids)
+			found := make(map[int32]*RedditComment, len(results))
+			for _, result := range results {
+				if !result.GetNotFound() {
+					found[result.GetCommentID()] = result.GetComment()
+				}
+			}
+			return found, err
+		}),
+	}
+}
+
+// Post of the ID, or an error matching ErrNotFound when there is none
+func (l *Loader) Post(ctx context.Context, postID int32) (*RedditPost, error) {
+	return l.posts.load(ctx, postID)
+}
+
+// Comment of the ID, or an error matching ErrNotFound when there is none
+func (l *Loader) Comment(ctx context.Context, commentID int32) (*RedditComment, error) {
+	return l.comments.load(ctx, commentID)
+}
+
+// Posts of the IDs in order, in as few calls as the batch size allows
+func (l *Loader) Posts(ctx context.Context, postIDs []int32) ([]*RedditPost, error) {
+	return loadAll(ctx, postIDs, l.Post)
+}
+
+// Comments of the IDs in order, in as few calls as the batch size allows
+func (l *Loader) Comments(ctx context.Context, commentIDs []int32) ([]*RedditComment, error) {
+	return loadAll(ctx, commentIDs, l.Comment)
+}
+
+func loadAll[T any](ctx context.Context, ids []int32, load func(context.Context, int32) (T, error)) ([]T, error) {
+	values := make([]T, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id int32) {
+			defer wg.Done()
+			values[i], errs[i] = load(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// Result of loading an ID, complete once done is closed
+type loaded[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// IDs gathered to be fetched together
+type batch[T any] struct {
+	ctx     context.Context
+	ids     []int32
+	results []*loaded[T]
+	timer   *time.Timer
+}
+
+type batcher[T any] struct {
+	op       string
+	kind     string // Of content, in not found errors
+	wait     time.Duration
+	timeout  time.Duration
+	maxBatch int
+	fetch    func(ctx context.Context, ids []int32) (map[int32]T, error)
+
+	mu      sync.Mutex
+	cache   map[int32]*loaded[T]
+	pending *batch[T]
+}
+
+func newBatcher[T any](o *loaderOptions, op string, kind string, fetch func(context.Context, []int32) (map[int32]T, error)) *batcher[T] {
+	return &batcher[T]{op: op, kind: kind, wait: o.wait, timeout: o.timeout, maxBatch: o.maxBatch, fetch: fetch, cache: map[int32]*loaded[T]{}}
+}
+
+func (b *batcher[T]) load(ctx context.Context, id int32) (T, error) {
+	b.mu.Lock()
+	result, ok := b.cache[id]
+	if !ok {
+		result = &loaded[T]{done: make(chan struct{})}
+		b.cache[id] = result
+		b.add(ctx, id, result)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero T
+		return zero, newError(b.op, status.FromContextError(ctx.Err()).Err())
+	}
+}
+
+// Add the ID to the pending batch, starting one that waits for more IDs
+// unless it is full. Called with mu held.
+func (b *batcher[T]) add(ctx context.Context, id int32, result *loaded[T]) {
+	if b.pending == nil {
+		// Callers giving up does not fail the batch for the others
+		pending := &batch[T]{ctx: context.WithoutCancel(ctx)}
+		pending.timer = time.AfterFunc(b.wait, func() {
+			b.mu.Lock()
+			if b.pending != pending {
+				// Sent when it was full
+				b.mu.Unlock()
+				return
+			}
+			b.pending = nil
+			b.mu.Unlock()
+			b.send(pending)
+		})
+		b.pending = pending
+	}
+	pending := b.pending
+	pending.ids = append(pending.ids, id)
+	pending.results = append(pending.results, result)
+	if len(pending.ids) >= b.maxBatch {
+		pending.timer.Stop()
+		b.pending = nil
+		go b.send(pending)
+	}
+}
+
+// Fetch the batch in the context of its first caller, with the loader's
+// timeout instead of the caller's deadline
+func (b *batcher[T]) send(pending *batch[T]) {
+	ctx, cancel := context.WithTimeout(pending.ctx, b.timeout)
+	defer cancel()
+	found, err := b.fetch(ctx, pending.ids)
+	for i, id := range pending.ids {
+		result := pending.results[i]
+		if err != nil {
+			result.err = err
+		} else if value, ok := found[id]; ok {
+			result.value = value
+		} else {
+			result.err = newError(b.op, status.Error(codes.NotFound, fmt.Sprintf("%s %d not found", b.kind, id)))
+		}
+		close(result.done)
+	}
+	if err != nil {
+		// Failed IDs are fetched again when loaded again
+		b.mu.Lock()
+		for _, id := range pending.ids {
+			delete(b.cache, id)
+		}
+		b.mu.Unlock()
+	}
+}
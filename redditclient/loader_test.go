@@ -0,0 +1,149 @@
+package redditclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reddit server holding the posts and comments of IDs below 10, recording
+// the IDs of each batch
+type batchServer struct {
+	pb.UnimplementedRedditServer
+	mu      sync.Mutex
+	batches [][]int32
+	fail    bool
+	stall   bool // Until the call is cancelled
+}
+
+func (s *batchServer) record(ctx context.Context, ids []int32) error {
+	s.mu.Lock()
+	s.batches = append(s.batches, ids)
+	fail, stall := s.fail, s.stall
+	s.mu.Unlock()
+	if fail {
+		return status.Error(codes.Unavailable, "server restarting")
+	}
+	if stall {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return nil
+}
+
+func (s *batchServer) BatchGetPosts(ctx context.Context, in *pb.BatchGetPostsRequest) (*pb.BatchGetPostsResponse, error) {
+	if err := s.record(ctx, in.GetPostIDs()); err != nil {
+		return nil, err
+	}
+	response := &pb.BatchGetPostsResponse{}
+	for _, id := range in.GetPostIDs() {
+		if id < 10 {
+			response.Results = append(response.Results, &pb.PostResult{PostID: id, Post: &pb.Post{Id: id}})
+		} else {
+			response.Results = append(response.Results, &pb.PostResult{PostID: id, NotFound: true})
+		}
+	}
+	return response, nil
+}
+
+func (s *batchServer) BatchGetComments(ctx context.Context, in *pb.BatchGetCommentsRequest) (*pb.BatchGetCommentsResponse, error) {
+	if err := s.record(ctx, in.GetCommentIDs()); err != nil {
+		return nil, err
+	}
+	response := &pb.BatchGetCommentsResponse{}
+	for _, id := range in.GetCommentIDs() {
+		response.Results = append(response.Results, &pb.CommentResult{CommentID: id, Comment: &pb.Comment{Id: id}})
+	}
+	return response, nil
+}
+
+func TestLoaderBatches(t *testing.T) {
+	srv := &batchServer{}
+	loader := NewLoader(newTestClient(t, srv), WithLoaderWait(20*time.Millisecond))
+	ctx := context.Background()
+
+	posts, err := loader.Posts(ctx, []int32{3, 1, 3, 2})
+	require.NoError(t, err)
+	require.Len(t, posts, 4)
+	for i, id := range []int32{3, 1, 3, 2} {
+		assert.Equal(t, id, posts[i].GetId())
+	}
+	require.Len(t, srv.batches, 1)
+	assert.ElementsMatch(t, []int32{1, 2, 3}, srv.batches[0])
+
+	// Loaded posts are not fetched again
+	post, err := loader.Post(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), post.GetId())
+	assert.Len(t, srv.batches, 1)
+
+	_, err = loader.Post(ctx, 10)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	comments, err := loader.Comments(ctx, []int32{5, 6})
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), comments[1].GetId())
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	srv := &batchServer{}
+	// Full batches are sent without waiting
+	loader := NewLoader(newTestClient(t, srv), WithLoaderWait(time.Hour), WithMaxBatch(2))
+
+	comments, err := loader.Comments(context.Background(), []int32{1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Len(t, comments, 4)
+	require.Len(t, srv.batches, 2)
+	assert.Len(t, srv.batches[0], 2)
+	assert.Len(t, srv.batches[1], 2)
+}
+
+func TestLoaderErrors(t *testing.T) {
+	srv := &batchServer{fail: true}
+	loader := NewLoader(newTestClient(t, srv, WithRetry(RetryPolicy{})))
+	ctx := context.Background()
+
+	_, err := loader.Post(ctx, 1)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	// Failures are not cached
+	srv.mu.Lock()
+	srv.fail = false
+	srv.mu.Unlock()
+	post, err := loader.Post(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), post.GetId())
+
+	// Callers giving up stop waiting
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = NewLoader(newTestClient(t, srv), WithLoaderWait(time.Hour)).Post(ctx, 1)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestLoaderTimeout(t *testing.T) {
+	srv := &batchServer{stall: true}
+	loader := NewLoader(newTestClient(t, srv, WithRetry(RetryPolicy{})), WithLoaderTimeout(50*time.Millisecond))
+	ctx := context.Background()
+
+	// A stalled call fails even for callers without a deadline
+	_, err := loader.Post(ctx, 1)
+	assert.ErrorIs(t, err, ErrDeadlineExceeded)
+	loader.posts.mu.Lock()
+	assert.Empty(t, loader.posts.cache)
+	loader.posts.mu.Unlock()
+
+	srv.mu.Lock()
+	srv.stall = false
+	srv.mu.Unlock()
+	post, err := loader.Post(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), post.GetId())
+}
@@ -0,0 +1,130 @@
+package redditclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	requestIDKey = "x-request-id"
+)
+
+// Format a message for debug logging, cut to at most limit bytes
+func truncatePayload(msg any, limit int) string {
+	s := fmt.Sprint(msg)
+	if limit > 0 && len(s) > limit {
+		return s[:limit] + fmt.Sprintf("...(%d bytes truncated)", len(s)-limit)
+	}
+	return s
+}
+
+// Tag the outgoing call with a new request ID, unless the caller set one
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if values := md.Get(requestIDKey); len(values) > 0 {
+		return ctx, values[0]
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	id := hex.EncodeToString(b)
+	return metadata.AppendToOutgoingContext(ctx, requestIDKey, id), id
+}
+
+func callLogger(logger *slog.Logger, cc *grpc.ClientConn, method string, id string) *slog.Logger {
+	return logger.With(
+		slog.String("method", strings.TrimPrefix(method, "/")),
+		slog.String("peer", cc.Target()),
+		slog.String("request_id", id),
+	)
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", status.Code(err).String()),
+	}
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	logger.LogAttrs(ctx, level, "Finished call", attrs...)
+}
+
+// Log every unary call, with payloads at debug level only
+func unaryLoggingInterceptor(logger *slog.Logger, payloadLimit int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		ctx, id := withRequestID(ctx)
+		logger := callLogger(logger, cc, method, id)
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Debug("Sending request", slog.String("payload", truncatePayload(req, payloadLimit)))
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		if err == nil && logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Debug("Received response", slog.String("payload", truncatePayload(reply, payloadLimit)))
+		}
+		logCall(ctx, logger, start, err)
+		return err
+	}
+}
+
+// Client stream that logs each message at debug level
+type loggingClientStream struct {
+	grpc.ClientStream
+	logger       *slog.Logger
+	payloadLimit int
+	start        time.Time
+}
+
+func (s *loggingClientStream) SendMsg(m any) error {
+	if s.logger.Enabled(s.Context(), slog.LevelDebug) {
+		s.logger.Debug("Sending message", slog.String("payload", truncatePayload(m, s.payloadLimit)))
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *loggingClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		if s.logger.Enabled(s.Context(), slog.LevelDebug) {
+			s.logger.Debug("Received message", slog.String("payload", truncatePayload(m, s.payloadLimit)))
+		}
+		return nil
+	}
+	if err == io.EOF {
+		logCall(s.Context(), s.logger, s.start, nil)
+	} else {
+		logCall(s.Context(), s.logger, s.start, err)
+	}
+	return err
+}
+
+// Log every streaming call, with messages at debug level only
+func streamLoggingInterceptor(logger *slog.Logger, payloadLimit int) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		ctx, id := withRequestID(ctx)
+		logger := callLogger(logger, cc, method, id)
+		logger.Debug("Started stream")
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logCall(ctx, logger, start, err)
+			return nil, err
+		}
+		return &loggingClientStream{ClientStream: stream, logger: logger, payloadLimit: payloadLimit, start: start}, nil
+	}
+}
@@ -0,0 +1,38 @@
+package redditclient
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	attempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_grpc_client_attempts_total",
+		Help: "Total number of call attempts made by the client, including retries and hedges, by method and status code.",
+	}, []string{"method", "code"})
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_grpc_client_retries_total",
+		Help: "Total number of calls retried after a failed attempt, by method.",
+	}, []string{"method"})
+	hedges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_grpc_client_hedges_total",
+		Help: "Total number of hedged attempts started while an earlier attempt was still running, by method.",
+	}, []string{"method"})
+)
+
+// RegisterMetrics registers the client metrics, shared by every client of
+// the process, with reg
+func RegisterMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{attempts, retries, hedges} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func observeAttempt(fullMethod string, err error) {
+	attempts.WithLabelValues(strings.TrimPrefix(fullMethod, "/"), status.Code(err).String()).Inc()
+}
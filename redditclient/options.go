@@ -0,0 +1,152 @@
+package redditclient
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	DefaultAddress      = "localhost:50051"
+	defaultPayloadLimit = 1024
+)
+
+type options struct {
+	address            string
+	creds              credentials.TransportCredentials
+	token              string
+	timeout            time.Duration
+	retry              RetryPolicy
+	hedging            HedgingPolicy
+	logger             *slog.Logger
+	payloadLimit       int
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	dialOptions        []grpc.DialOption
+}
+
+// Option configures a RedditAPIClient
+type Option func(*options)
+
+// WithAddress sets the host:port of the server, DefaultAddress by default
+func WithAddress(address string) Option {
+	return func(o *options) { o.address = address }
+}
+
+// WithTransportCredentials sets the credentials of the connection, which
+// is insecure by default. See TLSCredentials.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(o *options) { o.creds = creds }
+}
+
+// WithToken sends token as a bearer token in the authorization metadata of
+// every call. Tokens are only sent over transport credentials with TLS.
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithTimeout sets the deadline of unary calls whose context has none
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry sets the retry policy, DefaultRetryPolicy by default. The
+// timeout of WithTimeout applies to each attempt.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy }
+}
+
+// WithHedging sends hedged attempts of the policy's methods, which are then
+// no longer retried by the retry policy. Hedging is disabled by default.
+func WithHedging(policy HedgingPolicy) Option {
+	return func(o *options) { o.hedging = policy }
+}
+
+// WithLogger logs every call to logger, with payloads at debug level only
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithLogPayloadLimit sets the maximum bytes of a payload logged at debug
+// level, 0 for no limit
+func WithLogPayloadLimit(limit int) Option {
+	return func(o *options) { o.payloadLimit = limit }
+}
+
+// WithUnaryInterceptors adds interceptors to unary calls, run for each
+// attempt after the client's own timeout and logging
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) { o.unaryInterceptors = append(o.unaryInterceptors, interceptors...) }
+}
+
+// WithStreamInterceptors adds interceptors to streaming calls, run after
+// the client's own logging
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *options) { o.streamInterceptors = append(o.streamInterceptors, interceptors...) }
+}
+
+// WithDialOptions passes options through to grpc.Dial, after the ones the
+// other options produce
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, dialOptions...) }
+}
+
+// PostOption sets optional fields of a post created by CreatePost
+type PostOption func(*RedditPost)
+
+// WithVideoURL attaches a video to the post
+func WithVideoURL(url string) PostOption {
+	return func(p *RedditPost) { p.VideoURL = &url }
+}
+
+// WithImageURL attaches an image to the post
+func WithImageURL(url string) PostOption {
+	return func(p *RedditPost) { p.ImageURL = &url }
+}
+
+type voteOptions struct {
+	voterID int32
+}
+
+// VoteOption configures a vote of VotePost or VoteComment
+type VoteOption func(*voteOptions)
+
+// WithVoter sends the ID of the user voting, which votes leave unset by
+// default
+func WithVoter(userID int32) VoteOption {
+	return func(o *voteOptions) { o.voterID = userID }
+}
+
+func newVoteOptions(opts []VoteOption) voteOptions {
+	var o voteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Apply the default deadline to unary calls without one
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Bearer token sent with every call
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,185 @@
+package redditclient
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Read RPCs, safe to repeat. Votes join them once the server records who
+// voted and a repeated vote no longer changes the score again.
+var idempotentMethods = []string{
+	"GetPost", "GetComment", "BatchGetPosts", "BatchGetComments", "GetTopComments", "ExpandCommentBranch",
+	"ListPosts", "SearchPosts", "GetSubReddit", "ListSubReddits",
+}
+
+// RetryPolicy retries failed unary calls with jittered exponential backoff
+type RetryPolicy struct {
+	MaxAttempts    int           // Attempts per call including the first, 1 or less disables retries
+	InitialBackoff time.Duration // Upper bound of the first backoff
+	MaxBackoff     time.Duration // Upper bound of any backoff
+	Multiplier     float64       // Growth of the backoff bound after each attempt
+	Codes          []codes.Code  // Status codes worth another attempt
+	Methods        []string      // RPC names without the service
+}
+
+// DefaultRetryPolicy retries reads failing with Unavailable or DeadlineExceeded
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Codes:          []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+	Methods:        idempotentMethods,
+}
+
+// HedgingPolicy sends another attempt of a slow call without waiting for
+// the previous one to fail, and keeps the first successful response
+type HedgingPolicy struct {
+	MaxAttempts int           // Attempts per call including the first, 1 or less disables hedging
+	Delay       time.Duration // Wait before each further attempt
+	Methods     []string      // RPC names without the service
+}
+
+// DefaultHedgingPolicy hedges reads slower than 50 milliseconds
+var DefaultHedgingPolicy = HedgingPolicy{
+	MaxAttempts: 2,
+	Delay:       50 * time.Millisecond,
+	Methods:     idempotentMethods,
+}
+
+func containsMethod(methods []string, fullMethod string) bool {
+	name := path.Base(fullMethod)
+	for _, method := range methods {
+		if method == name || strings.TrimPrefix(method, "/") == strings.TrimPrefix(fullMethod, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Random wait before the given retry, with full jitter
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	bound := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry))
+	if bound > float64(p.MaxBackoff) {
+		bound = float64(p.MaxBackoff)
+	}
+	if bound <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(bound) + 1))
+}
+
+// Retry failed calls to the retry policy's methods, or hedge slow calls to
+// the hedging policy's methods, and count every attempt
+func retryInterceptor(retry RetryPolicy, hedging HedgingPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call := func(ctx context.Context, reply any) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			observeAttempt(method, err)
+			return err
+		}
+		if out, ok := reply.(proto.Message); ok && hedging.MaxAttempts > 1 && containsMethod(hedging.Methods, method) {
+			return hedgeCall(ctx, method, out, hedging, retry, call)
+		}
+		if retry.MaxAttempts > 1 && containsMethod(retry.Methods, method) {
+			return retryCall(ctx, method, reply, retry, call)
+		}
+		return call(ctx, reply)
+	}
+}
+
+func retryCall(ctx context.Context, method string, reply any, policy RetryPolicy, call func(context.Context, any) error) error {
+	err := call(ctx, reply)
+	for attempt := 1; attempt < policy.MaxAttempts && err != nil && policy.retryable(err); attempt++ {
+		select {
+		case <-time.After(policy.backoff(attempt - 1)):
+		case <-ctx.Done():
+			// No time left for another attempt
+			return err
+		}
+		retries.WithLabelValues(strings.TrimPrefix(method, "/")).Inc()
+		err = call(ctx, reply)
+	}
+	return err
+}
+
+type hedgeResult struct {
+	reply proto.Message
+	err   error
+}
+
+// Start another attempt whenever the previous ones are slower than the
+// hedging delay or fail with a retryable code, and keep the first response
+// that succeeds or fails with a non retryable code
+func hedgeCall(ctx context.Context, method string, out proto.Message, policy HedgingPolicy, retry RetryPolicy, call func(context.Context, any) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, policy.MaxAttempts)
+	started := 0
+	start := func() {
+		started++
+		attemptReply := proto.Clone(out)
+		proto.Reset(attemptReply)
+		go func() {
+			results <- hedgeResult{reply: attemptReply, err: call(ctx, attemptReply)}
+		}()
+	}
+	start()
+	timer := time.NewTimer(policy.Delay)
+	defer timer.Stop()
+
+	var err error
+	for finished := 0; finished < started; {
+		select {
+		case result := <-results:
+			finished++
+			if result.err == nil {
+				proto.Reset(out)
+				proto.Merge(out, result.reply)
+				return nil
+			}
+			if !retry.retryable(result.err) {
+				return result.err
+			}
+			err = result.err
+			if started < policy.MaxAttempts && ctx.Err() == nil {
+				retries.WithLabelValues(strings.TrimPrefix(method, "/")).Inc()
+				start()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(policy.Delay)
+			}
+		case <-timer.C:
+			if started < policy.MaxAttempts {
+				hedges.WithLabelValues(strings.TrimPrefix(method, "/")).Inc()
+				start()
+				timer.Reset(policy.Delay)
+			}
+		}
+	}
+	return err
+}
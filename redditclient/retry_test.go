@@ -0,0 +1,112 @@
+package redditclient
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reddit server failing the first calls of each RPC with Unavailable
+type flakyServer struct {
+	pb.UnimplementedRedditServer
+	failures  int32
+	getPosts  atomic.Int32
+	votePosts atomic.Int32
+}
+
+func (s *flakyServer) GetPost(ctx context.Context, in *pb.GetPostRequest) (*pb.GetPostResponse, error) {
+	if s.getPosts.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &pb.GetPostResponse{Post: &pb.Post{Id: in.GetPostID()}}, nil
+}
+
+func (s *flakyServer) VotePost(ctx context.Context, in *pb.VotePostRequest) (*pb.VotePostResponse, error) {
+	if s.votePosts.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &pb.VotePostResponse{Score: 1}, nil
+}
+
+// Reddit server whose first GetPost call hangs until canceled
+type slowServer struct {
+	pb.UnimplementedRedditServer
+	calls atomic.Int32
+}
+
+func (s *slowServer) GetPost(ctx context.Context, in *pb.GetPostRequest) (*pb.GetPostResponse, error) {
+	if s.calls.Add(1) == 1 {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return &pb.GetPostResponse{Post: &pb.Post{Id: in.GetPostID()}}, nil
+}
+
+var fastRetries = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+	Multiplier:     2,
+	Codes:          []codes.Code{codes.Unavailable},
+	Methods:        idempotentMethods,
+}
+
+func TestRetryReads(t *testing.T) {
+	srv := &flakyServer{failures: 2}
+	s := newTestClient(t, srv, WithRetry(fastRetries))
+	before := testutil.ToFloat64(retries.WithLabelValues("reddit.Reddit/GetPost"))
+
+	post, err := s.GetPost(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), post.GetId())
+	assert.Equal(t, int32(3), srv.getPosts.Load())
+	assert.Equal(t, float64(2), testutil.ToFloat64(retries.WithLabelValues("reddit.Reddit/GetPost"))-before)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv := &flakyServer{failures: 10}
+	s := newTestClient(t, srv, WithRetry(fastRetries))
+
+	_, err := s.GetPost(context.Background(), 7)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(4), srv.getPosts.Load())
+}
+
+func TestVotesAreNotRetried(t *testing.T) {
+	srv := &flakyServer{failures: 1}
+	s := newTestClient(t, srv, WithRetry(fastRetries))
+
+	_, err := s.VotePost(context.Background(), 1, true)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, int32(1), srv.votePosts.Load())
+}
+
+func TestHedging(t *testing.T) {
+	srv := &slowServer{}
+	s := newTestClient(t, srv, WithHedging(HedgingPolicy{MaxAttempts: 2, Delay: 20 * time.Millisecond, Methods: idempotentMethods}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	post, err := s.GetPost(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), post.GetId())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestBackoffBounds(t *testing.T) {
+	for retry := 0; retry < 10; retry++ {
+		backoff := DefaultRetryPolicy.backoff(retry)
+		assert.GreaterOrEqual(t, backoff, time.Duration(0))
+		assert.LessOrEqual(t, backoff, DefaultRetryPolicy.MaxBackoff)
+	}
+}
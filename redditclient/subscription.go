@@ -0,0 +1,248 @@
+package redditclient
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultReconnectPolicy reconnects broken subscriptions for as long as
+// their context lasts, backing off up to 5 seconds between attempts
+var DefaultReconnectPolicy = RetryPolicy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Codes:          []codes.Code{codes.Unavailable, codes.Unknown, codes.Internal, codes.Aborted, codes.DeadlineExceeded},
+}
+
+// Event delivered by a Subscription: a score update, or a marker that the
+// subscription reconnected after its stream broke, sent once the new stream
+// delivers its first update. Updates sent while it was disconnected are
+// lost, so consumers should resync on Resumed. An update with NotFound set
+// reports content that does not exist, which is removed from the
+// subscription.
+type Event struct {
+	Update  *Update
+	Resumed bool
+}
+
+type content struct {
+	contentType ContentType
+	id          int32
+}
+
+// Subscription to score updates of a changing set of posts and comments,
+// kept over a MonitorUpdates stream that is reopened whenever it breaks
+type Subscription struct {
+	client *RedditAPIClient
+	policy RetryPolicy
+	events chan Event
+	cancel context.CancelFunc
+	done   chan struct{}
+	delay  func(retry int) time.Duration // Before the given reconnect in a row
+
+	mu       sync.Mutex
+	contents map[content]bool
+	stream   MonitorStream
+	err      error
+
+	// Held over sends, which may block on flow control, instead of mu. It is
+	// taken before mu is released so that sends keep the order of changes.
+	sendMu sync.Mutex
+}
+
+// SubscriptionOption configures a Subscription
+type SubscriptionOption func(*Subscription)
+
+// WithReconnectPolicy sets the backoff and status codes of reconnects,
+// DefaultReconnectPolicy by default. A MaxAttempts above 0 limits the
+// attempts in a row before giving up.
+func WithReconnectPolicy(policy RetryPolicy) SubscriptionOption {
+	return func(s *Subscription) { s.policy = policy }
+}
+
+// Subscribe opens a subscription, initially to no content. It connects in
+// the background and ends when ctx is done or Close is called.
+func (c *RedditAPIClient) Subscribe(ctx context.Context, opts ...SubscriptionOption) *Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		client:   c,
+		policy:   DefaultReconnectPolicy,
+		events:   make(chan Event, 16),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		contents: map[content]bool{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.delay == nil {
+		s.delay = s.policy.backoff
+	}
+	go s.run(ctx)
+	return s
+}
+
+// Add a post or comment to the subscription
+func (s *Subscription) Add(contentType ContentType, id int32) {
+	s.update(content{contentType, id}, true)
+}
+
+// Remove a post or comment from the subscription
+func (s *Subscription) Remove(contentType ContentType, id int32) {
+	s.update(content{contentType, id}, false)
+}
+
+func (s *Subscription) update(c content, subscribe bool) {
+	s.mu.Lock()
+	if s.contents[c] == subscribe {
+		s.mu.Unlock()
+		return
+	}
+	if subscribe {
+		s.contents[c] = true
+	} else {
+		delete(s.contents, c)
+	}
+	stream := s.stream
+	if stream == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.sendMu.Lock()
+	s.mu.Unlock()
+	defer s.sendMu.Unlock()
+	// A failed send breaks the stream, and the reconnect sends the whole set
+	stream.Send(&UpdateRequest{ContentType: c.contentType, ContentID: c.id, Unsubscribe: !subscribe})
+}
+
+// Updates returns the channel of events, closed when the subscription ends
+func (s *Subscription) Updates() <-chan Event {
+	return s.events
+}
+
+// Close ends the subscription and waits for its stream to close
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// Err returns the error that ended the subscription, nil if it was closed
+// or its context is done
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Open the stream and reopen it whenever it breaks
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.done)
+	defer close(s.events)
+
+	connected := false
+	failures := 0
+	for {
+		established, err := s.connect(ctx, connected)
+		if ctx.Err() != nil {
+			return
+		}
+		if established {
+			// The first reconnect after a working stream waits the least
+			connected = true
+			failures = 0
+		}
+		failures++
+		if (err != io.EOF && !s.policy.retryable(err)) || (s.policy.MaxAttempts > 0 && failures >= s.policy.MaxAttempts) {
+			s.mu.Lock()
+			s.err = newError("MonitorUpdates", err)
+			s.mu.Unlock()
+			return
+		}
+		select {
+		case <-time.After(s.delay(failures - 1)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Open a stream, subscribe to the current contents and forward its updates
+// until it breaks. Reports whether the stream was established.
+func (s *Subscription) connect(ctx context.Context, reconnect bool) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := s.client._client.MonitorUpdates(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Changes made from now on are sent after the current contents
+	s.mu.Lock()
+	contents := make([]content, 0, len(s.contents))
+	for c := range s.contents {
+		contents = append(contents, c)
+	}
+	s.stream = stream
+	s.sendMu.Lock()
+	s.mu.Unlock()
+	defer func() {
+		// Cancelling first unblocks sends on the stream
+		cancel()
+		s.mu.Lock()
+		s.stream = nil
+		s.mu.Unlock()
+	}()
+	for _, c := range contents {
+		if err := stream.Send(&UpdateRequest{ContentType: c.contentType, ContentID: c.id}); err != nil {
+			s.sendMu.Unlock()
+			return false, recvError(stream)
+		}
+	}
+	s.sendMu.Unlock()
+
+	// The first message tells whether the server accepted the stream
+	update, err := stream.Recv()
+	if err != nil {
+		return false, err
+	}
+	if reconnect && !s.deliver(ctx, Event{Resumed: true}) {
+		return true, ctx.Err()
+	}
+	for {
+		if update.GetNotFound() {
+			// The server stopped monitoring it, so reconnects do not ask again
+			s.mu.Lock()
+			delete(s.contents, content{update.GetContentType(), update.GetContentID()})
+			s.mu.Unlock()
+		}
+		if !s.deliver(ctx, Event{Update: update}) {
+			return true, ctx.Err()
+		}
+		if update, err = stream.Recv(); err != nil {
+			return true, err
+		}
+	}
+}
+
+func (s *Subscription) deliver(ctx context.Context, event Event) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// The status of a stream whose send failed, which gRPC only reports on receive
+func recvError(stream MonitorStream) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package redditclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reddit server answering each subscription with an update, whose first
+// stream breaks after its first update
+type monitorServer struct {
+	pb.UnimplementedRedditServer
+	streams  chan int
+	requests chan *pb.MonitorUpdatesRequest
+}
+
+func (s *monitorServer) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error {
+	n := <-s.streams
+	s.streams <- n + 1
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.requests <- in
+		if in.GetUnsubscribe() {
+			continue
+		}
+		if err := stream.Send(&pb.MonitorUpdatesResponse{ContentType: in.GetContentType(), ContentID: in.GetContentID(), Score: int32(n)}); err != nil {
+			return err
+		}
+		if n == 0 {
+			return status.Error(codes.Unavailable, "server restarting")
+		}
+	}
+}
+
+// Reddit server failing its streams before their first update, except the
+// third, which breaks after it, and those from the fifth, which stay open
+type restartingServer struct {
+	pb.UnimplementedRedditServer
+	mu      sync.Mutex
+	streams int
+}
+
+func (s *restartingServer) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error {
+	s.mu.Lock()
+	n := s.streams
+	s.streams++
+	s.mu.Unlock()
+	if n == 2 || n >= 4 {
+		if err := stream.Send(&pb.MonitorUpdatesResponse{ContentType: pb.ContentType_POST, ContentID: int32(n)}); err != nil {
+			return err
+		}
+	}
+	if n >= 4 {
+		<-stream.Context().Done()
+		return nil
+	}
+	return status.Error(codes.Unavailable, "server restarting")
+}
+
+// Reddit server holding only post 1, whose first stream breaks after
+// answering two subscriptions
+type missingServer struct {
+	pb.UnimplementedRedditServer
+	streams  atomic.Int32
+	requests chan *pb.MonitorUpdatesRequest
+}
+
+func (s *missingServer) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error {
+	first := s.streams.Add(1) == 1
+	for answered := 0; ; answered++ {
+		if first && answered == 2 {
+			return status.Error(codes.Unavailable, "server restarting")
+		}
+		in, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.requests <- in
+		response := &pb.MonitorUpdatesResponse{ContentType: in.GetContentType(), ContentID: in.GetContentID(), Score: 1, NotFound: in.GetContentID() != 1}
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+func nextEvent(t *testing.T, sub *Subscription) Event {
+	select {
+	case event, ok := <-sub.Updates():
+		require.True(t, ok, "subscription ended: %v", sub.Err())
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event received")
+		return Event{}
+	}
+}
+
+func TestSubscriptionResumes(t *testing.T) {
+	srv := &monitorServer{streams: make(chan int, 1), requests: make(chan *pb.MonitorUpdatesRequest, 10)}
+	srv.streams <- 0
+	s := newTestClient(t, srv)
+
+	policy := DefaultReconnectPolicy
+	policy.InitialBackoff = time.Millisecond
+	sub := s.Subscribe(context.Background(), WithReconnectPolicy(policy))
+	sub.Add(ContentPost, 1)
+
+	event := nextEvent(t, sub)
+	assert.Equal(t, int32(1), event.Update.GetContentID())
+	assert.Equal(t, int32(0), event.Update.GetScore())
+
+	// The second stream is resubscribed to the same content
+	event = nextEvent(t, sub)
+	assert.True(t, event.Resumed)
+	event = nextEvent(t, sub)
+	assert.Equal(t, int32(1), event.Update.GetContentID())
+	assert.Equal(t, int32(1), event.Update.GetScore())
+
+	// Removing the content unsubscribes from it on the open stream
+	<-srv.requests
+	<-srv.requests
+	sub.Remove(ContentPost, 1)
+	req := <-srv.requests
+	assert.True(t, req.GetUnsubscribe())
+	assert.Equal(t, int32(1), req.GetContentID())
+
+	require.NoError(t, sub.Close())
+	_, ok := <-sub.Updates()
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+func TestSubscriptionGivesUp(t *testing.T) {
+	s := newTestClient(t, notFoundServer{})
+
+	sub := s.Subscribe(context.Background())
+	sub.Add(ContentPost, 1)
+	_, ok := <-sub.Updates()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), &Error{Code: codes.Unimplemented})
+}
+
+func TestSubscriptionBackoff(t *testing.T) {
+	s := newTestClient(t, &restartingServer{})
+	retries := make(chan int, 10)
+	recordDelay := func(sub *Subscription) {
+		sub.delay = func(retry int) time.Duration {
+			retries <- retry
+			return 0
+		}
+	}
+
+	sub := s.Subscribe(context.Background(), recordDelay)
+	event := nextEvent(t, sub)
+	assert.Equal(t, int32(2), event.Update.GetContentID())
+	event = nextEvent(t, sub)
+	assert.True(t, event.Resumed)
+	event = nextEvent(t, sub)
+	assert.Equal(t, int32(4), event.Update.GetContentID())
+	require.NoError(t, sub.Close())
+
+	// Backoff starts over once a stream has worked
+	close(retries)
+	var got []int
+	for retry := range retries {
+		got = append(got, retry)
+	}
+	assert.Equal(t, []int{0, 1, 0, 1}, got)
+}
+
+func TestSubscriptionDropsMissingContent(t *testing.T) {
+	srv := &missingServer{requests: make(chan *pb.MonitorUpdatesRequest, 10)}
+	s := newTestClient(t, srv)
+
+	policy := DefaultReconnectPolicy
+	policy.InitialBackoff = time.Millisecond
+	sub := s.Subscribe(context.Background(), WithReconnectPolicy(policy))
+	defer sub.Close()
+	sub.Add(ContentPost, 1)
+	sub.Add(ContentPost, 99)
+
+	notFound := map[int32]bool{}
+	for len(notFound) < 2 {
+		event := nextEvent(t, sub)
+		notFound[event.Update.GetContentID()] = event.Update.GetNotFound()
+	}
+	assert.Equal(t, map[int32]bool{1: false, 99: true}, notFound)
+
+	// The reconnect only subscribes to the content that exists
+	event := nextEvent(t, sub)
+	assert.True(t, event.Resumed)
+	event = nextEvent(t, sub)
+	assert.Equal(t, int32(1), event.Update.GetContentID())
+	sub.Add(ContentPost, 2)
+	<-srv.requests
+	<-srv.requests
+	var resubscribed []int32
+	for req := range srv.requests {
+		if req.GetContentID() == 2 {
+			break
+		}
+		resubscribed = append(resubscribed, req.GetContentID())
+	}
+	assert.Equal(t, []int32{1}, resubscribed)
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/protobuf/proto"
+)
+
+/**
+ *
+ * LRU cache
+ *
+ */
+
+// Kinds of cached reads
+const (
+	cachedPost = iota
+	cachedTopComments
+)
+
+// Content whose cached reads are invalidated together, such as the top
+// comments of a post of every quantity
+type cacheGroup struct {
+	kind int
+	id   int
+}
+
+type cacheKey struct {
+	cacheGroup
+	quantity int
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	value   any
+	expires time.Time
+}
+
+// Bounded LRU cache of reads, each served until its TTL passes or its group
+// is invalidated
+type lruCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // Of *cacheEntry, most recently used first
+	entries map[cacheKey]*list.Element
+	groups  map[cacheGroup]map[cacheKey]struct{}
+	// Invalidations of groups, shared by every 256th group, so that a read
+	// which raced with a write is not cached after the write invalidated it
+	generations [256]uint64
+}
+
+func newLRUCache(size int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[cacheKey]*list.Element{},
+		groups:  map[cacheGroup]map[cacheKey]struct{}{},
+	}
+}
+
+// IDs are sequential, so that groups spread evenly over the generations
+func (c *lruCache) generation(group cacheGroup) *uint64 {
+	return &c.generations[uint(group.id*2+group.kind)%uint(len(c.generations))]
+}
+
+// Value of key, or the generation to put its value at after reading it
+func (c *lruCache) get(key cacheKey) (any, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(element)
+			return entry.value, true, 0
+		}
+		c.remove(element)
+	}
+	return nil, false, *c.generation(key.cacheGroup)
+}
+
+// Cache the value of key read at generation, unless its group was
+// invalidated since
+func (c *lruCache) put(key cacheKey, value any, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if *c.generation(key.cacheGroup) != generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: c.now().Add(c.ttl)})
+	keys, ok := c.groups[key.cacheGroup]
+	if !ok {
+		keys = map[cacheKey]struct{}{}
+		c.groups[key.cacheGroup] = keys
+	}
+	keys[key] = struct{}{}
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+	cacheEntries.Set(float64(c.order.Len()))
+}
+
+func (c *lruCache) invalidate(group cacheGroup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	*c.generation(group)++
+	for key := range c.groups[group] {
+		c.remove(c.entries[key])
+	}
+}
+
+func (c *lruCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.generations {
+		c.generations[i]++
+	}
+	c.order.Init()
+	clear(c.entries)
+	clear(c.groups)
+	cacheEntries.Set(0)
+}
+
+func (c *lruCache) remove(element *list.Element) {
+	key := c.order.Remove(element).(*cacheEntry).key
+	delete(c.entries, key)
+	keys := c.groups[key.cacheGroup]
+	delete(keys, key)
+	if len(keys) == 0 {
+		delete(c.groups, key.cacheGroup)
+	}
+	cacheEntries.Set(float64(c.order.Len()))
+}
+
+/**
+ *
+ * Cached storage
+ *
+ */
+
+// Storage serving posts and top comments from an LRU cache, which writes
+// through it invalidate. Writes by other servers sharing the database are
+// seen once the TTL passes, which bounds how stale a served score can be.
+type cachedStorage struct {
+	Storage
+	cache *lruCache
+}
+
+func newCachedStorage(s Storage, size int, ttl time.Duration) *cachedStorage {
+	return &cachedStorage{Storage: s, cache: newLRUCache(size, ttl)}
+}
+
+// Read through the cache, copying values in and out as callers may change them
+func cachedRead[T proto.Message](c *cachedStorage, method string, key cacheKey, read func() (T, error)) (T, error) {
+	cached, ok, generation := c.cache.get(key)
+	observeCache(method, ok)
+	if ok {
+		return cloneValue(cached.(T)), nil
+	}
+	value, err := read()
+	if err != nil {
+		return value, err
+	}
+	c.cache.put(key, cloneValue(value), generation)
+	return value, nil
+}
+
+func cloneValue[T proto.Message](value T) T {
+	return proto.Clone(value).(T)
+}
+
+func (c *cachedStorage) GetPost(ctx context.Context, id int) (*pb.Post, error) {
+	key := cacheKey{cacheGroup: cacheGroup{cachedPost, id}}
+	return cachedRead(c, "GetPost", key, func() (*pb.Post, error) {
+		return c.Storage.GetPost(ctx, id)
+	})
+}
+
+func (c *cachedStorage) GetTopComments(ctx context.Context, postID int, quantity int) ([]*pb.Comment, error) {
+	key := cacheKey{cacheGroup{cachedTopComments, postID}, quantity}
+	top, err := cachedRead(c, "GetTopComments", key, func() (*pb.GetTopCommentsResponse, error) {
+		comments, err := c.Storage.GetTopComments(ctx, postID, quantity)
+		return &pb.GetTopCommentsResponse{Comments: comments}, err
+	})
+	return top.GetComments(), err
+}
+
+func (c *cachedStorage) CreatePost(ctx context.Context, post *pb.Post) (int, error) {
+	id, err := c.Storage.CreatePost(ctx, post)
+	if err == nil {
+		c.invalidatePost(id)
+	}
+	return id, err
+}
+
+func (c *cachedStorage) VotePost(ctx context.Context, id int, upvote bool) (int, error) {
+	score, err := c.Storage.VotePost(ctx, id, upvote)
+	if err == nil {
+		c.invalidatePost(id)
+	}
+	return score, err
+}
+
+func (c *cachedStorage) CreateComment(ctx context.Context, comment *pb.Comment) (int, error) {
+	id, err := c.Storage.CreateComment(ctx, comment)
+	if err == nil && comment.GetParent() == pb.ContentType_POST {
+		c.invalidateTopComments(int(comment.GetParentID()))
+	}
+	return id, err
+}
+
+// A vote can move a comment into or out of the top comments of its post,
+// which is looked up to invalidate them
+func (c *cachedStorage) VoteComment(ctx context.Context, id int, upvote bool) (int, error) {
+	score, err := c.Storage.VoteComment(ctx, id, upvote)
+	if err != nil {
+		return score, err
+	}
+	c.invalidateComment(ctx, id)
+	return score, nil
+}
+
+func (c *cachedStorage) Restore(ctx context.Context, r recordReader) (int, error) {
+	defer c.cache.purge()
+	return c.Storage.Restore(ctx, r)
+}
+
+// Forget the post, after its content, score or state changed
+func (c *cachedStorage) invalidatePost(id int) {
+	c.cache.invalidate(cacheGroup{cachedPost, id})
+}
+
+// Forget the top comments of the post, after one of its comments was
+// created, or changed its content, score or state
+func (c *cachedStorage) invalidateTopComments(postID int) {
+	c.cache.invalidate(cacheGroup{cachedTopComments, postID})
+}
+
+// Forget the top comments the comment may be in, or everything when it
+// cannot be looked up
+func (c *cachedStorage) invalidateComment(ctx context.Context, id int) {
+	comment, err := c.Storage.GetComment(ctx, id)
+	if err != nil {
+		c.cache.purge()
+		return
+	}
+	if comment.GetParent() == pb.ContentType_POST {
+		c.invalidateTopComments(int(comment.GetParentID()))
+	}
+}
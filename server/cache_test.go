@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+)
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	key := func(id int) cacheKey { return cacheKey{cacheGroup: cacheGroup{cachedPost, id}} }
+	put := func(id int) {
+		_, _, generation := cache.get(key(id))
+		cache.put(key(id), id, generation)
+	}
+
+	put(1)
+	put(2)
+	_, ok, _ := cache.get(key(1))
+	require.True(t, ok)
+	// The least recently used entry is evicted
+	put(3)
+	_, ok, _ = cache.get(key(2))
+	assert.False(t, ok)
+	value, ok, _ := cache.get(key(1))
+	require.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(cacheEntries))
+
+	// Expired entries are removed when read
+	now = now.Add(time.Minute)
+	_, ok, _ = cache.get(key(1))
+	assert.False(t, ok)
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheEntries))
+
+	// A read which raced with an invalidation is not cached
+	_, _, generation := cache.get(key(4))
+	cache.invalidate(key(4).cacheGroup)
+	cache.put(key(4), 4, generation)
+	_, ok, _ = cache.get(key(4))
+	assert.False(t, ok)
+}
+
+func TestCachedStorage(t *testing.T) {
+	s := newCachedStorage(newTestSQLClient(t), 100, time.Minute)
+	ctx := context.Background()
+	hits := cacheRequests.WithLabelValues("GetPost", "hit")
+	misses := cacheRequests.WithLabelValues("GetPost", "miss")
+	hitsBefore, missesBefore := testutil.ToFloat64(hits), testutil.ToFloat64(misses)
+
+	post, err := s.GetPost(ctx, 1)
+	require.NoError(t, err)
+	// Changes by callers do not reach the cache
+	post.Title = "Changed"
+	post, err = s.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Cat Video", post.GetTitle())
+	assert.Equal(t, hitsBefore+1, testutil.ToFloat64(hits))
+	assert.Equal(t, missesBefore+1, testutil.ToFloat64(misses))
+
+	// Votes invalidate the post
+	_, err = s.VotePost(ctx, 1, true)
+	require.NoError(t, err)
+	post, err = s.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), post.GetScore())
+
+	// Votes on a comment invalidate the top comments of its post
+	comments, err := s.GetTopComments(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, int32(4), comments[0].GetId())
+	_, err = s.VoteComment(ctx, 6, true)
+	require.NoError(t, err)
+	comments, err = s.GetTopComments(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, int32(6), comments[0].GetId())
+
+	// So do new comments
+	_, err = s.CreateComment(ctx, &pb.Comment{Content: "First", Score: 10, Parent: pb.ContentType_POST, ParentID: 2})
+	require.NoError(t, err)
+	comments, err = s.GetTopComments(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "First", comments[0].GetContent())
+
+	// Missing posts are not cached
+	_, err = s.GetPost(ctx, 99)
+	assert.Error(t, err)
+	assert.Empty(t, s.cache.entries[cacheKey{cacheGroup: cacheGroup{cachedPost, 99}}])
+}
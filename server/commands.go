@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Maintenance command, run against the storage instead of serving
+type command struct {
+	usage string
+	run   func(ctx context.Context, s Storage, args []string) error
+}
+
+var commands = map[string]command{
+	"import":  {"import [-submissions FILE] [-comments FILE] [-batch N]", runImport},
+	"export":  {"export [-format ndjson|binary] [-gzip] [-o FILE]", runExport},
+	"restore": {"restore [-format ndjson|binary] FILE", runRestore},
+	"seed":    {"seed [-seed N] [-users N] [-subreddits N] [-posts N] [-comments N] [-depth N] [-start DATE] [-days N] [-o FILE] [-format ndjson|binary]", runSeed},
+}
+
+var errUsage = errors.New("invalid usage")
+
+// Run the command named by the first argument
+func runCommand(ctx context.Context, s Storage, args []string) error {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		commandUsage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	err := cmd.run(ctx, s, args[1:])
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(os.Stderr, "Usage: server [flags] %s\n", cmd.usage)
+	}
+	return err
+}
+
+func commandUsage() {
+	usages := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		usages = append(usages, cmd.usage)
+	}
+	sort.Strings(usages)
+	fmt.Fprintf(os.Stderr, "Usage: server [flags] [COMMAND]\n\nServes the Reddit API without a command.\n\nCommands:\n  %s\n", strings.Join(usages, "\n  "))
+}
+
+// Flags of a command, which reports usage errors instead of exiting
+func commandFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Origins other than the server's own that browsers may call it from
+type corsPolicy struct {
+	anyOrigin bool
+	origins   map[string]bool
+}
+
+// Parse a comma separated list of origins, with * for any origin
+func parseCORSOrigins(spec string) *corsPolicy {
+	c := &corsPolicy{origins: map[string]bool{}}
+	for _, origin := range strings.Split(spec, ",") {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		switch origin {
+		case "":
+		case "*":
+			c.anyOrigin = true
+		default:
+			c.origins[strings.ToLower(origin)] = true
+		}
+	}
+	return c
+}
+
+// Whether a cross-origin caller may read responses. A nil policy allows none.
+func (c *corsPolicy) allowed(origin string) bool {
+	if c == nil || origin == "" {
+		return false
+	}
+	return c.anyOrigin || c.origins[strings.ToLower(origin)]
+}
+
+// Whether a cross-origin caller was listed by name, and so may call with the
+// user's cookies and client certificate. A nil policy lists none.
+func (c *corsPolicy) listed(origin string) bool {
+	return c != nil && c.origins[strings.ToLower(origin)]
+}
+
+// Whether a request comes from a page of the server itself, from a listed
+// origin, or from a caller that is not a browser and sends no origin. Used
+// for WebSockets, which browsers open with credentials whatever the origin.
+func (c *corsPolicy) allowRequest(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return c.listed(origin)
+}
+
+// Add CORS headers for allowed origins and answer their preflight requests
+func (c *corsPolicy) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		if !c.allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		// Only listed origins may send cookies and client certificates, else
+		// any page could make calls as the user
+		if c.listed(origin) {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", "Retry-After, X-Request-Id")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+)
+
+func TestEndToEndPosts(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	ctx := context.Background()
+
+	post, err := client.CreatePost(ctx, "Dog Video", "Woof", 1, 2, redditclient.WithVideoURL("https://example.com/dog.mp4"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), post.GetId())
+	assert.Equal(t, "https://example.com/dog.mp4", post.GetVideoURL())
+
+	score, err := client.VotePost(ctx, post.GetId(), true, redditclient.WithVoter(1))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), score)
+	score, err = client.VotePost(ctx, post.GetId(), false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), score)
+
+	post, err = client.GetPost(ctx, post.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "Dog Video", post.GetTitle())
+	assert.Equal(t, int32(2), post.GetAuthor().GetId())
+
+	posts, next, err := client.ListPosts(ctx, 1, 1, "")
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "Cat Video", posts[0].GetTitle())
+	posts, next, err = client.ListPosts(ctx, 1, 1, next)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "Dog Video", posts[0].GetTitle())
+	assert.Empty(t, next)
+
+	posts, err = client.SearchPosts(ctx, "video", 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	_, err = client.GetPost(ctx, 100)
+	assert.ErrorIs(t, err, redditclient.ErrNotFound)
+	_, err = client.SearchPosts(ctx, "", 0, 10)
+	assert.ErrorIs(t, err, redditclient.ErrInvalidArgument)
+}
+
+func TestEndToEndComments(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	ctx := context.Background()
+
+	reply, err := client.ReplyToPost(ctx, 1, 1, "Cute")
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), reply.GetId())
+	nested, err := client.ReplyToComment(ctx, reply.GetId(), 2, "Agreed")
+	require.NoError(t, err)
+
+	score, err := client.VoteComment(ctx, reply.GetId(), true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), score)
+
+	comment, err := client.GetComment(ctx, nested.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "Agreed", comment.GetContent())
+	assert.Equal(t, redditclient.ContentComment, comment.GetParent())
+	assert.Equal(t, reply.GetId(), comment.GetParentID())
+
+	// The most upvoted comments first
+	comments, err := client.GetTopComments(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, int32(1), comments[0].GetId())
+	assert.Equal(t, reply.GetId(), comments[1].GetId())
+
+	comments, err = client.ExpandCommentBranch(ctx, reply.GetId(), 10)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Agreed", comments[0].GetContent())
+}
+
+func TestEndToEndBatchGet(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	ctx := context.Background()
+
+	posts, err := client.BatchGetPosts(ctx, []int32{2, 99, 1, 2})
+	require.NoError(t, err)
+	require.Len(t, posts, 4)
+	assert.Equal(t, "Cat Image", posts[0].GetPost().GetTitle())
+	assert.True(t, posts[1].GetNotFound())
+	assert.Equal(t, int32(99), posts[1].GetPostID())
+	assert.Nil(t, posts[1].GetPost())
+	assert.Equal(t, "Cat Video", posts[2].GetPost().GetTitle())
+	assert.Equal(t, int32(2), posts[3].GetPost().GetId())
+
+	loader := redditclient.NewLoader(client)
+	comments, err := loader.Comments(ctx, []int32{3, 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Aliquet sodales hendrerit", comments[0].GetContent())
+	_, err = loader.Comment(ctx, 99)
+	assert.ErrorIs(t, err, redditclient.ErrNotFound)
+
+	_, err = client.BatchGetComments(ctx, make([]int32, maxBatchSize+1))
+	assert.ErrorIs(t, err, redditclient.ErrInvalidArgument)
+}
+
+func TestEndToEndSubReddits(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	ctx := context.Background()
+
+	subReddit, err := client.GetSubReddit(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "r/aww", subReddit.GetName())
+	assert.Equal(t, []string{"tag-1", "tag-2", "tag-3"}, subReddit.GetTags())
+
+	subReddits, next, err := client.ListSubReddits(ctx, 10, "")
+	require.NoError(t, err)
+	assert.Len(t, subReddits, 2)
+	assert.Empty(t, next)
+}
+
+func TestEndToEndMonitorUpdates(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.MonitorUpdates(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&redditclient.UpdateRequest{ContentType: redditclient.ContentPost, ContentID: 1}))
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), update.GetScore())
+
+	// A new subscription sends the current scores right away
+	_, err = client.VotePost(ctx, 1, true)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&redditclient.UpdateRequest{ContentType: redditclient.ContentComment, ContentID: 1}))
+	scores := map[redditclient.ContentType]int32{}
+	for len(scores) < 2 {
+		update, err := stream.Recv()
+		require.NoError(t, err)
+		scores[update.GetContentType()] = update.GetScore()
+	}
+	assert.Equal(t, int32(3), scores[redditclient.ContentPost])
+	assert.Equal(t, int32(2), scores[redditclient.ContentComment])
+
+	watch, err := client.WatchUpdates(ctx, &redditclient.UpdateRequest{ContentType: redditclient.ContentComment, ContentID: 2})
+	require.NoError(t, err)
+	update, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), update.GetContentID())
+}
+
+func TestEndToEndMonitorMissingContent(t *testing.T) {
+	client := newTestHarness(t, nil).client
+	sub := client.Subscribe(context.Background())
+	defer sub.Close()
+
+	next := func() redditclient.Event {
+		select {
+		case event, ok := <-sub.Updates():
+			require.True(t, ok, "subscription ended: %v", sub.Err())
+			require.False(t, event.Resumed)
+			return event
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no event received")
+			return redditclient.Event{}
+		}
+	}
+
+	// A missing post is reported on its own, and the stream stays open
+	sub.Add(redditclient.ContentPost, 1)
+	sub.Add(redditclient.ContentPost, 99)
+	found := map[int32]bool{}
+	for len(found) < 2 {
+		event := next()
+		found[event.Update.GetContentID()] = !event.Update.GetNotFound()
+	}
+	assert.Equal(t, map[int32]bool{1: true, 99: false}, found)
+
+	sub.Add(redditclient.ContentComment, 1)
+	for {
+		event := next()
+		assert.False(t, event.Update.GetNotFound())
+		if event.Update.GetContentType() == redditclient.ContentComment {
+			assert.Equal(t, int32(2), event.Update.GetScore())
+			break
+		}
+	}
+	assert.NoError(t, sub.Err())
+}
+
+func TestEndToEndRateLimits(t *testing.T) {
+	client := newTestHarness(t, map[string]limit{"VotePost": {rate: 0, burst: 1}}).client
+	ctx := context.Background()
+
+	_, err := client.VotePost(ctx, 1, true)
+	require.NoError(t, err)
+	_, err = client.VotePost(ctx, 1, true)
+	assert.ErrorIs(t, err, redditclient.ErrResourceExhausted)
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Formats of exported datasets: NDJSON of the JSON mapping of the records,
+// or records of binary protobuf each prefixed by its varint length
+const (
+	formatNDJSON = "ndjson"
+	formatBinary = "binary"
+)
+
+/**
+ *
+ * Record streams
+ *
+ */
+
+type recordWriter interface {
+	Write(record *pb.ExportRecord) error
+}
+
+type recordReader interface {
+	// Read the next record, or return io.EOF at the end of the stream
+	Read() (*pb.ExportRecord, error)
+}
+
+func newRecordWriter(w io.Writer, format string) (recordWriter, error) {
+	switch format {
+	case formatNDJSON:
+		return ndjsonRecordWriter{w}, nil
+	case formatBinary:
+		return binaryRecordWriter{w}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, want %s or %s", format, formatNDJSON, formatBinary)
+	}
+}
+
+func newRecordReader(r io.Reader, format string) (recordReader, error) {
+	br := bufio.NewReader(r)
+	switch format {
+	case formatNDJSON:
+		return &ndjsonRecordReader{r: br}, nil
+	case formatBinary:
+		return binaryRecordReader{br}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, want %s or %s", format, formatNDJSON, formatBinary)
+	}
+}
+
+type ndjsonRecordWriter struct {
+	w io.Writer
+}
+
+func (w ndjsonRecordWriter) Write(record *pb.ExportRecord) error {
+	data, err := protojson.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(data, '\n'))
+	return err
+}
+
+type ndjsonRecordReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (r *ndjsonRecordReader) Read() (*pb.ExportRecord, error) {
+	for {
+		data, err := r.r.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return nil, err
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		r.line++
+		if strings.TrimSpace(string(data)) == "" {
+			continue
+		}
+		record := &pb.ExportRecord{}
+		if err := protojson.Unmarshal(data, record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return record, nil
+	}
+}
+
+type binaryRecordWriter struct {
+	w io.Writer
+}
+
+func (w binaryRecordWriter) Write(record *pb.ExportRecord) error {
+	_, err := protodelim.MarshalTo(w.w, record)
+	return err
+}
+
+type binaryRecordReader struct {
+	r *bufio.Reader
+}
+
+func (r binaryRecordReader) Read() (*pb.ExportRecord, error) {
+	record := &pb.ExportRecord{}
+	if err := protodelim.UnmarshalFrom(r.r, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+/**
+ *
+ * Export
+ *
+ */
+
+// Write every user, subreddit, post and comment, hidden ones included, in
+// that order so that records only refer to earlier ones
+func (c *SQLClient) Export(ctx context.Context, w recordWriter) error {
+	return exportDB(ctx, c.db, &sql.TxOptions{ReadOnly: true}, w)
+}
+
+// Export the tables of a database, read in a transaction of opts which
+// sees a consistent snapshot, as the server may be writing meanwhile
+func exportDB(ctx context.Context, db *sql.DB, opts *sql.TxOptions, w recordWriter) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = exportRows(ctx, tx, w, `SELECT id FROM "user" ORDER BY id`, func(row rowScanner) (*pb.ExportRecord, error) {
+		user := &pb.User{}
+		err := row.Scan(&user.Id)
+		return &pb.ExportRecord{Record: &pb.ExportRecord_User{User: user}}, err
+	})
+	if err != nil {
+		return err
+	}
+	err = exportRows(ctx, tx, w, "SELECT id, name, state, tags FROM subreddit ORDER BY id", func(row rowScanner) (*pb.ExportRecord, error) {
+		subReddit, err := scanSubReddit(row)
+		return &pb.ExportRecord{Record: &pb.ExportRecord_SubReddit{SubReddit: subReddit}}, err
+	})
+	if err != nil {
+		return err
+	}
+	err = exportRows(ctx, tx, w, "SELECT "+postColumns+" FROM post ORDER BY id", func(row rowScanner) (*pb.ExportRecord, error) {
+		post, err := scanPost(row)
+		return &pb.ExportRecord{Record: &pb.ExportRecord_Post{Post: post}}, err
+	})
+	if err != nil {
+		return err
+	}
+	return exportRows(ctx, tx, w, "SELECT "+commentColumns+" FROM comment ORDER BY id", func(row rowScanner) (*pb.ExportRecord, error) {
+		comment, err := scanComment(row)
+		return &pb.ExportRecord{Record: &pb.ExportRecord_Comment{Comment: comment}}, err
+	})
+}
+
+// Write the records scanned from the rows of query as they are read
+func exportRows(ctx context.Context, tx *sql.Tx, w recordWriter, query string, scan func(rowScanner) (*pb.ExportRecord, error)) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		record, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func runExport(ctx context.Context, s Storage, args []string) (err error) {
+	fs := commandFlags("export")
+	format := fs.String("format", formatNDJSON, "The format of the records: ndjson or binary")
+	compress := fs.Bool("gzip", false, "Compress the output with gzip")
+	output := fs.String("o", "-", "The file to write, - for stdout")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, f.Close()) }()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+	out = bw
+	var zw *gzip.Writer
+	if *compress {
+		zw = gzip.NewWriter(bw)
+		out = zw
+	}
+	w, err := newRecordWriter(out, *format)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Export(ctx, w); err != nil {
+		return err
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+/**
+ *
+ * Restore
+ *
+ */
+
+// Insert the records read from r with their IDs, into a database without
+// users, subreddits, posts or comments. Nothing is inserted unless every
+// record is.
+func (c *SQLClient) Restore(ctx context.Context, r recordReader) (int, error) {
+	if err := c.CreateSchema(ctx); err != nil {
+		return 0, err
+	}
+	return restoreDB(ctx, c.db, r, func(query string) string { return query }, nil)
+}
+
+// Restore the records into a database whose placeholders are those of the
+// ? placeholders rewritten by bind, calling finish before committing
+func restoreDB(ctx context.Context, db *sql.DB, r recordReader, bind func(string) string, finish func(context.Context, *sql.Tx) error) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"user", "subreddit", "post", "comment"} {
+		var rows int
+		if err := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)).Scan(&rows); err != nil {
+			return 0, err
+		}
+		if rows > 0 {
+			return 0, fmt.Errorf("the %s table is not empty", table)
+		}
+	}
+
+	restored := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reading record %d: %w", restored+1, err)
+		}
+		if err := restoreRecord(ctx, tx, bind, record); err != nil {
+			return 0, fmt.Errorf("restoring record %d: %w", restored+1, err)
+		}
+		restored++
+	}
+	if finish != nil {
+		if err := finish(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	return restored, tx.Commit()
+}
+
+// Value of an unset optional column
+func nullable[T any](set bool, value T) any {
+	if !set {
+		return nil
+	}
+	return value
+}
+
+func restoreRecord(ctx context.Context, tx *sql.Tx, bind func(string) string, record *pb.ExportRecord) error {
+	var err error
+	switch r := record.GetRecord().(type) {
+	case *pb.ExportRecord_User:
+		_, err = tx.ExecContext(ctx, bind(`INSERT INTO "user" (id) VALUES (?)`), r.User.GetId())
+	case *pb.ExportRecord_SubReddit:
+		s := r.SubReddit
+		_, err = tx.ExecContext(ctx, bind("INSERT INTO subreddit (id, name, state, tags) VALUES (?, ?, ?, ?)"),
+			s.GetId(), s.GetName(), int32(s.GetState()), strings.Join(s.GetTags(), ","))
+	case *pb.ExportRecord_Post:
+		p := r.Post
+		_, err = tx.ExecContext(ctx, bind("INSERT INTO post ("+postColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+			p.GetId(), p.GetTitle(), p.GetContent(), p.GetSubReddit().GetId(),
+			nullable(p.VideoURL != nil, p.GetVideoURL()), nullable(p.ImageURL != nil, p.GetImageURL()),
+			nullable(p.Author != nil, p.GetAuthor().GetId()), p.GetScore(), int32(p.GetState()), dateValue(p.GetPublicationDate()))
+	case *pb.ExportRecord_Comment:
+		c := r.Comment
+		_, err = tx.ExecContext(ctx, bind("INSERT INTO comment ("+commentColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)"),
+			c.GetId(), c.GetContent(), nullable(c.Author != nil, c.GetAuthor().GetId()), c.GetScore(),
+			int32(c.GetState()), dateValue(c.GetPublicationDate()), int32(c.GetParent()), c.GetParentID())
+	default:
+		err = errors.New("empty record")
+	}
+	return err
+}
+
+func runRestore(ctx context.Context, s Storage, args []string) error {
+	fs := commandFlags("restore")
+	format := fs.String("format", formatNDJSON, "The format of the records: ndjson or binary")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+
+	var in io.Reader = os.Stdin
+	if file := fs.Arg(0); file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	in, err := decompress(in)
+	if err != nil {
+		return err
+	}
+	r, err := newRecordReader(in, *format)
+	if err != nil {
+		return err
+	}
+
+	restored, err := s.Restore(ctx, r)
+	if err != nil {
+		return err
+	}
+	slog.Info("Restored", slog.Int("records", restored))
+	return nil
+}
+
+// Reader of r, decompressing it when it starts with the gzip header
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(header) == 2 && header[0] == 0x1f && header[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportNDJSON(t *testing.T, client *SQLClient) string {
+	var out bytes.Buffer
+	w, err := newRecordWriter(&out, formatNDJSON)
+	require.NoError(t, err)
+	require.NoError(t, client.Export(context.Background(), w))
+	return out.String()
+}
+
+func TestExport(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(exportNDJSON(t, newTestSQLClient(t))), "\n")
+	require.Len(t, lines, 2+2+2+6)
+	assert.JSONEq(t, `{"user": {"id": 1}}`, lines[0])
+	assert.JSONEq(t, `{"subReddit": {"id": 2, "name": "r/funny", "state": "PRIVATE", "tags": ["tag-4", "tag-5", "tag-6"]}}`, lines[3])
+	assert.JSONEq(t, `{"post": {"id": 2, "title": "Cat Image", "content": "Ullamcorper mollis hendrerit netus urna pharetra lectus tempor justo vel", "subReddit": {"id": 2}, "imageURL": "https://i.imgur.com/Ozgwrq2_d.webp", "state": "LOCKED_POST"}}`, lines[5])
+	assert.JSONEq(t, `{"comment": {"id": 6, "content": "Massa suscipit gravida", "author": {"id": 1}, "state": "LOCKED_COMMENT", "parent": "POST", "parentID": 2}}`, lines[11])
+}
+
+func TestExportRestore(t *testing.T) {
+	source := newTestSQLClient(t)
+	ctx := context.Background()
+	// A reply by a deleted user, as imported
+	_, err := source.db.ExecContext(ctx, "INSERT INTO comment ("+commentColumns+") VALUES (7, 'Hi', NULL, 1, 1, '2023-11-14', 2, 3)")
+	require.NoError(t, err)
+	want := exportNDJSON(t, source)
+
+	for _, args := range [][]string{{"-format", "ndjson"}, {"-format", "binary", "-gzip"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "reddit.export")
+			require.NoError(t, runCommand(ctx, source, append([]string{"export", "-o", file}, args...)))
+
+			target := newEmptySQLClient(t)
+			require.NoError(t, runCommand(ctx, target, []string{"restore", "-format", args[1], file}))
+			assert.Equal(t, want, exportNDJSON(t, target))
+
+			reply, err := target.GetComment(ctx, 7)
+			require.NoError(t, err)
+			assert.Nil(t, reply.GetAuthor())
+			assert.Equal(t, int32(3), reply.GetParentID())
+			assert.Equal(t, int32(2023), reply.GetPublicationDate().GetYear())
+
+			err = runCommand(ctx, target, []string{"restore", "-format", args[1], file})
+			assert.ErrorContains(t, err, "the user table is not empty")
+		})
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	client := newEmptySQLClient(t)
+	file := filepath.Join(t.TempDir(), "reddit.ndjson")
+	require.NoError(t, os.WriteFile(file, []byte("{\"user\": {\"id\": 1}}\n\n{\"user\": {\"id\": 1}}\n"), 0o600))
+
+	err := runCommand(context.Background(), client, []string{"restore", file})
+	assert.ErrorContains(t, err, "restoring record 2: ")
+	assert.Equal(t, "", exportNDJSON(t, client), "nothing is restored")
+
+	require.NoError(t, os.WriteFile(file, []byte("{\"user\": {\"id\": 1}}\n{\"post\": 3}\n"), 0o600))
+	err = runCommand(context.Background(), client, []string{"restore", file})
+	assert.ErrorContains(t, err, "reading record 2: line 2: ")
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	forwardedForKey  = "x-forwarded-for"
+	forwardedUserKey = "x-forwarded-user"
+
+	// Network of the in-process connection used by the gateway
+	inProcessNetwork = "bufconn"
+
+	// Largest request body the gateway reads
+	maxBodySize = 1 << 20
+)
+
+// Mapping of an HTTP endpoint onto a Reddit RPC. Path parameters and query
+// parameters fill the request fields of the same name, and the JSON body
+// fills the request field named by body, or the whole request for "*".
+type route struct {
+	method  string
+	pattern string
+	rpc     string
+	body    string
+	aliases map[string]string // query parameter name to request field name
+	summary string
+}
+
+var routes = []route{
+	{method: http.MethodPost, pattern: "/v1/posts", rpc: "CreatePost", body: "post", summary: "Create a post"},
+	{method: http.MethodGet, pattern: "/v1/posts/{postID}", rpc: "GetPost", summary: "Retrieve Post content"},
+	{method: http.MethodPost, pattern: "/v1/posts/{postID}:vote", rpc: "VotePost", body: "*", summary: "Upvote or downvote a Post"},
+	{method: http.MethodGet, pattern: "/v1/posts/{postID}/comments", rpc: "GetTopComments", aliases: map[string]string{"top": "quantity"}, summary: "Retrieving a list of N most upvoted comments under a post"},
+	{method: http.MethodPost, pattern: "/v1/posts:batchGet", rpc: "BatchGetPosts", body: "*", summary: "Retrieve several Posts at once"},
+	{method: http.MethodPost, pattern: "/v1/comments", rpc: "CreateComment", body: "comment", summary: "Create a Comment"},
+	{method: http.MethodGet, pattern: "/v1/comments/{commentID}", rpc: "GetComment", summary: "Retrieve a Comment"},
+	{method: http.MethodPost, pattern: "/v1/comments/{commentID}:vote", rpc: "VoteComment", body: "*", summary: "Upvote or downvote a Comment"},
+	{method: http.MethodPost, pattern: "/v1/comments:batchGet", rpc: "BatchGetComments", body: "*", summary: "Retrieve several Comments at once"},
+	{method: http.MethodGet, pattern: "/v1/comments/{commentID}/replies", rpc: "ExpandCommentBranch", aliases: map[string]string{"top": "quantity"}, summary: "Expand a comment branch"},
+	{method: http.MethodGet, pattern: "/v1/posts", rpc: "ListPosts", summary: "List the posts of a subreddit, or of every subreddit"},
+	{method: http.MethodGet, pattern: "/v1/posts:search", rpc: "SearchPosts", summary: "Search posts by title and content"},
+	{method: http.MethodGet, pattern: "/v1/subreddits", rpc: "ListSubReddits", summary: "List the subreddits that are not hidden"},
+	{method: http.MethodGet, pattern: "/v1/subreddits/{subRedditID}", rpc: "GetSubReddit", summary: "Retrieve a SubReddit"},
+	{method: http.MethodGet, pattern: "/v1/updates", rpc: "WatchUpdates", summary: "Watch updates to posts and comments, as newline delimited JSON or server-sent events"},
+}
+
+// Match a request path against a route pattern and extract its parameters
+func matchPattern(pattern string, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range patternSegments {
+		if !strings.HasPrefix(segment, "{") {
+			if segment != pathSegments[i] {
+				return nil, false
+			}
+			continue
+		}
+		// A parameter may be followed by a custom verb, as in {postID}:vote
+		name, verb, _ := strings.Cut(strings.TrimPrefix(segment, "{"), "}")
+		value, ok := strings.CutSuffix(pathSegments[i], verb)
+		if !ok || value == "" {
+			return nil, false
+		}
+		params[name] = value
+	}
+	return params, true
+}
+
+// Serve gs on an in-process listener and connect to it, so the gateway
+// reaches the service through the same interceptors as remote callers
+func dialInProcess(gs *grpc.Server) (*grpc.ClientConn, error) {
+	lis := bufconn.Listen(1024 * 1024)
+	go gs.Serve(lis)
+	return grpc.Dial("passthrough:///"+inProcessNetwork,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+}
+
+// HTTP/JSON gateway forwarding requests to the Reddit service over conn
+type gateway struct {
+	conn    grpc.ClientConnInterface
+	service protoreflect.ServiceDescriptor
+	cors    *corsPolicy
+}
+
+func newGateway(conn grpc.ClientConnInterface) *gateway {
+	return &gateway{
+		conn:    conn,
+		service: pb.File_reddit_reddit_proto.Services().ByName("Reddit"),
+	}
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/openapi.json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(g.openAPI())
+		return
+	case "/v1/updates/ws":
+		g.monitorUpdatesWebSocket(w, r)
+		return
+	}
+
+	pathMatched := false
+	for _, rt := range routes {
+		params, ok := matchPattern(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+		if rt.rpc == "WatchUpdates" {
+			g.watchUpdates(w, r)
+		} else {
+			g.unary(w, r, rt, params)
+		}
+		return
+	}
+	if pathMatched {
+		writeMessage(w, http.StatusMethodNotAllowed, status.Newf(codes.Unimplemented, "method %s not allowed", r.Method).Proto())
+		return
+	}
+	writeError(w, nil, status.Errorf(codes.NotFound, "no route for %s", r.URL.Path))
+}
+
+// Forward the caller identity, trace context and request headers as gRPC metadata
+func outgoingContext(r *http.Request) context.Context {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	md := metadata.MD{}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Set(forwardedForKey, host)
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		md.Set(forwardedUserKey, r.TLS.VerifiedChains[0][0].Subject.CommonName)
+	}
+	for _, header := range []string{"Authorization", "X-Request-Id"} {
+		if value := r.Header.Get(header); value != "" {
+			md.Set(header, value)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Build the request message of a unary RPC from the path, query and body
+func (g *gateway) newRequest(r *http.Request, rt route, method protoreflect.MethodDescriptor, params map[string]string) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	req := mt.New()
+
+	if rt.body != "" {
+		body, err := io.ReadAll(r.Body)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		} else if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "reading body: %v", err)
+		}
+		target := req
+		if rt.body != "*" {
+			field := req.Descriptor().Fields().ByName(protoreflect.Name(rt.body))
+			target = req.Mutable(field).Message()
+		}
+		if err := protojson.Unmarshal(body, target.Interface()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid body: %v", err)
+		}
+	}
+
+	for name, values := range r.URL.Query() {
+		if alias, ok := rt.aliases[name]; ok {
+			name = alias
+		}
+		if err := setField(req, name, values[0]); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range params {
+		if err := setField(req, name, value); err != nil {
+			return nil, err
+		}
+	}
+	return req.Interface(), nil
+}
+
+// Set a scalar request field from its string form
+func setField(msg protoreflect.Message, name string, value string) error {
+	field := msg.Descriptor().Fields().ByJSONName(name)
+	if field == nil {
+		field = msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	}
+	if field == nil || field.IsList() || field.IsMap() {
+		return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+	}
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q", name, value)
+		}
+		msg.Set(field, protoreflect.ValueOfInt32(int32(v)))
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q", name, value)
+		}
+		msg.Set(field, protoreflect.ValueOfBool(v))
+	case protoreflect.StringKind:
+		msg.Set(field, protoreflect.ValueOfString(value))
+	case protoreflect.EnumKind:
+		v := field.Enum().Values().ByName(protoreflect.Name(value))
+		if v == nil {
+			return status.Errorf(codes.InvalidArgument, "invalid %s %q", name, value)
+		}
+		msg.Set(field, protoreflect.ValueOfEnum(v.Number()))
+	default:
+		return status.Errorf(codes.InvalidArgument, "parameter %q cannot be set from the URL", name)
+	}
+	return nil
+}
+
+func (g *gateway) unary(w http.ResponseWriter, r *http.Request, rt route, params map[string]string) {
+	method := g.service.Methods().ByName(protoreflect.Name(rt.rpc))
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	req, err := g.newRequest(r, rt, method, params)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		st := status.Newf(codes.InvalidArgument, "body larger than %d bytes", tooLarge.Limit)
+		writeMessage(w, http.StatusRequestEntityTooLarge, st.Proto())
+		return
+	} else if err != nil {
+		writeError(w, nil, err)
+		return
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		writeError(w, nil, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	resp := mt.New().Interface()
+
+	var header metadata.MD
+	fullMethod := fmt.Sprintf("/%s/%s", g.service.FullName(), method.Name())
+	if err := g.conn.Invoke(outgoingContext(r), fullMethod, req, resp, grpc.Header(&header)); err != nil {
+		writeError(w, header, err)
+		return
+	}
+	writeMessage(w, http.StatusOK, resp)
+}
+
+func writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// Write a gRPC error as its status message, with the matching HTTP status
+func writeError(w http.ResponseWriter, header metadata.MD, err error) {
+	if values := header.Get(retryAfterKey); len(values) > 0 {
+		w.Header().Set("Retry-After", values[0])
+	}
+	st := status.Convert(err)
+	writeMessage(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+// HTTP status matching a gRPC status code
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
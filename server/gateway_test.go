@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc"
+)
+
+func newTestGateway(t *testing.T) *httptest.Server {
+	gs := grpc.NewServer()
+	pb.RegisterRedditServer(gs, &gRPCserver{storage: newTestSQLClient(t)})
+	conn, err := dialInProcess(gs)
+	require.NoError(t, err)
+	ts := httptest.NewServer(newGateway(conn))
+	t.Cleanup(func() {
+		ts.Close()
+		conn.Close()
+		gs.Stop()
+	})
+	return ts
+}
+
+// Send a request to the gateway and decode its JSON response
+func doJSON(t *testing.T, method string, url string, body string) (int, map[string]any) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	result := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return resp.StatusCode, result
+}
+
+func TestMatchPattern(t *testing.T) {
+	params, ok := matchPattern("/v1/posts/{postID}:vote", "/v1/posts/12:vote")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"postID": "12"}, params)
+
+	_, ok = matchPattern("/v1/posts/{postID}:vote", "/v1/posts/12")
+	assert.False(t, ok)
+	_, ok = matchPattern("/v1/posts/{postID}", "/v1/posts/12/comments")
+	assert.False(t, ok)
+}
+
+func TestGatewayPosts(t *testing.T) {
+	ts := newTestGateway(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/posts/1", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Cat Video", body["post"].(map[string]any)["title"])
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/posts/1:vote", `{"upvote": true}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(3), body["score"])
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/posts", `{"title": "Hello", "content": "World", "subReddit": {"id": 1}}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Hello", body["post"].(map[string]any)["title"])
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/posts/1/comments?top=1", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["comments"], 1)
+}
+
+func TestGatewayErrors(t *testing.T) {
+	ts := newTestGateway(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/posts/999", "")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, float64(5), body["code"])
+
+	code, _ = doJSON(t, http.MethodGet, ts.URL+"/v1/posts/abc", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doJSON(t, http.MethodDelete, ts.URL+"/v1/posts/1", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/posts", `{"title": "`+strings.Repeat("a", maxBodySize)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Equal(t, float64(3), body["code"])
+}
+
+func TestGatewayOpenAPI(t *testing.T) {
+	ts := newTestGateway(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/openapi.json", "")
+	assert.Equal(t, http.StatusOK, code)
+	paths := body["paths"].(map[string]any)
+	for _, rt := range routes {
+		assert.Contains(t, paths[rt.pattern], strings.ToLower(rt.method), rt.pattern)
+	}
+	schemas := body["components"].(map[string]any)["schemas"].(map[string]any)
+	assert.Contains(t, schemas, "reddit.Post")
+}
+
+func TestGatewayListsAndSearch(t *testing.T) {
+	ts := newTestGateway(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/posts?pageSize=1", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["posts"], 1)
+	assert.Equal(t, "1", body["nextPageToken"])
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/posts?pageSize=1&pageToken=1", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["posts"], 1)
+	assert.Nil(t, body["nextPageToken"])
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/posts:search?query=image", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["posts"], 1)
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/subreddits/2", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "r/funny", body["subReddit"].(map[string]any)["name"])
+
+	code, _ = doJSON(t, http.MethodGet, ts.URL+"/v1/posts?pageToken=abc", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// Serve gRPC-Web calls to gs and every other request through the HTTP/JSON
+// gateway, on a single HTTP listener. Browsers use WatchUpdates for server
+// streaming, or MonitorUpdates over the gRPC-Web WebSocket transport.
+func newHTTPHandler(gs *grpc.Server, gw *gateway, cors *corsPolicy) http.Handler {
+	web := grpcweb.WrapServer(gs,
+		// gRPC-Web always allows credentials, so * does not apply to it
+		grpcweb.WithOriginFunc(cors.listed),
+		grpcweb.WithWebsockets(true),
+		grpcweb.WithWebsocketOriginFunc(cors.allowRequest),
+	)
+	gw.cors = cors
+	rest := cors.handler(gw)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) || web.IsGrpcWebSocketRequest(r) || web.IsAcceptableGrpcCorsRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+		rest.ServeHTTP(w, r)
+	})
+}
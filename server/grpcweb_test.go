@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const testOrigin = "https://app.example.com"
+
+func newTestHTTPHandler(t *testing.T) *httptest.Server {
+	return newTestHTTPHandlerWithCORS(t, testOrigin)
+}
+
+func newTestHTTPHandlerWithCORS(t *testing.T, corsOrigins string) *httptest.Server {
+	gs := grpc.NewServer()
+	pb.RegisterRedditServer(gs, &gRPCserver{storage: newTestSQLClient(t)})
+	conn, err := dialInProcess(gs)
+	require.NoError(t, err)
+	ts := httptest.NewServer(newHTTPHandler(gs, newGateway(conn), parseCORSOrigins(corsOrigins)))
+	t.Cleanup(func() {
+		ts.Close()
+		conn.Close()
+		gs.Stop()
+	})
+	return ts
+}
+
+// Call an RPC with the gRPC-Web protocol and return the response body
+func postGRPCWeb(t *testing.T, url string, req proto.Message) *http.Response {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	frame = append(frame, data...)
+
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(frame))
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/grpc-web+proto")
+	httpReq.Header.Set("Origin", testOrigin)
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	return resp
+}
+
+// Read the next gRPC-Web frame, reporting whether it carries the trailers
+func readGRPCWebFrame(t *testing.T, r io.Reader) ([]byte, bool) {
+	header := make([]byte, 5)
+	_, err := io.ReadFull(r, header)
+	require.NoError(t, err)
+	data := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	_, err = io.ReadFull(r, data)
+	require.NoError(t, err)
+	return data, header[0]&0x80 != 0
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	ts := newTestHTTPHandler(t)
+
+	resp := postGRPCWeb(t, ts.URL+"/reddit.Reddit/GetPost", &pb.GetPostRequest{PostID: 1})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, testOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	data, trailer := readGRPCWebFrame(t, resp.Body)
+	require.False(t, trailer)
+	post := &pb.GetPostResponse{}
+	require.NoError(t, proto.Unmarshal(data, post))
+	assert.Equal(t, "Cat Video", post.GetPost().GetTitle())
+
+	data, trailer = readGRPCWebFrame(t, resp.Body)
+	assert.True(t, trailer)
+	assert.Contains(t, string(data), "grpc-status: 0")
+}
+
+func TestGRPCWebServerStreaming(t *testing.T) {
+	ts := newTestHTTPHandler(t)
+
+	resp := postGRPCWeb(t, ts.URL+"/reddit.Reddit/WatchUpdates", &pb.WatchUpdatesRequest{
+		Subscriptions: []*pb.MonitorUpdatesRequest{{ContentType: pb.ContentType_COMMENT, ContentID: 2}},
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data, trailer := readGRPCWebFrame(t, resp.Body)
+	require.False(t, trailer)
+	update := &pb.MonitorUpdatesResponse{}
+	require.NoError(t, proto.Unmarshal(data, update))
+	assert.Equal(t, pb.ContentType_COMMENT, update.GetContentType())
+	assert.Equal(t, int32(2), update.GetContentID())
+}
+
+func TestCORSPreflight(t *testing.T) {
+	ts := newTestHTTPHandler(t)
+
+	preflight := func(path string, origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, ts.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// gRPC-Web
+	resp := preflight("/reddit.Reddit/GetPost", testOrigin)
+	assert.Equal(t, testOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	resp = preflight("/reddit.Reddit/GetPost", "https://evil.example.com")
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// HTTP/JSON gateway
+	resp = preflight("/v1/posts", testOrigin)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, testOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "content-type,x-grpc-web", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	resp = preflight("/v1/posts", "https://evil.example.com")
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	ts := newTestHTTPHandlerWithCORS(t, "*")
+	preflight := func(path string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, ts.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", testOrigin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// Any page may read gateway responses, but not with the user's credentials
+	resp := preflight("/v1/posts")
+	assert.Equal(t, testOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/posts/1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", testOrigin)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, testOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+
+	// gRPC-Web always allows credentials, so it only serves listed origins
+	resp = preflight("/reddit.Reddit/GetPost")
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+	resp = postGRPCWeb(t, ts.URL+"/reddit.Reddit/GetPost", &pb.GetPostRequest{PostID: 1})
+	resp.Body.Close()
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Credentials"))
+
+	// So do WebSockets, which browsers open with credentials
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/updates/ws?post=1"
+	_, err = websocket.Dial(url, "", testOrigin)
+	assert.Error(t, err)
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	ts := newTestHTTPHandler(t)
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/updates/ws?post=1"
+
+	ws, err := websocket.Dial(url, "", testOrigin)
+	require.NoError(t, err)
+	ws.Close()
+
+	_, err = websocket.Dial(url, "", "https://evil.example.com")
+	assert.Error(t, err)
+}
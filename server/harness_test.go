@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tomy0000000/grpc-reddit/reddit/redditclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Real server, with its interceptors and a temp copy of the example
+// database, served over bufconn to a connected client
+type testHarness struct {
+	server *gRPCserver
+	client *redditclient.RedditAPIClient
+}
+
+// Boot the server for the test, without rate limits unless limits are
+// given, and connect a client with opts
+func newTestHarness(t *testing.T, limits map[string]limit, opts ...redditclient.Option) *testHarness {
+	s := &gRPCserver{storage: newTestSQLClient(t), subscriptions: newSubscriptionLimiter(0)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	gs := newGRPCServer(s, logger, 0, newRateLimiter(limits))
+
+	lis := bufconn.Listen(1024 * 1024)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	opts = append(opts, redditclient.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})))
+	client, err := redditclient.NewRedditAPIClient(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return &testHarness{server: s, client: client}
+}
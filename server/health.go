@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Anything whose reachability decides whether the server can serve requests
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping the database once and report the result on the health server, for
+// both the overall server and the Reddit service
+func checkHealth(ctx context.Context, hs *health.Server, db pinger, timeout time.Duration) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := db.Ping(ctx); err != nil {
+		slog.Warn("Database ping failed", slog.Any("error", err))
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	hs.SetServingStatus("", status)
+	hs.SetServingStatus(pb.Reddit_ServiceDesc.ServiceName, status)
+	return status
+}
+
+// Keep the health status in sync with database reachability until ctx is done
+func watchHealth(ctx context.Context, hs *health.Server, db pinger, interval time.Duration) {
+	last := checkHealth(ctx, hs, db, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status := checkHealth(ctx, hs, db, interval)
+			if status != last {
+				slog.Info("Health status changed", slog.String("status", status.String()))
+				last = status
+			}
+		}
+	}
+}
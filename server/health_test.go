@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	return p.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	hs := health.NewServer()
+	db := &fakePinger{}
+	ctx := context.Background()
+	request := &healthpb.HealthCheckRequest{Service: pb.Reddit_ServiceDesc.ServiceName}
+
+	checkHealth(ctx, hs, db, time.Second)
+	response, err := hs.Check(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+
+	// An unreachable database takes the server out of rotation
+	db.err = errors.New("database is locked")
+	checkHealth(ctx, hs, db, time.Second)
+	response, err = hs.Check(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+}
+
+func TestSQLClientPing(t *testing.T) {
+	assert.NoError(t, newTestSQLClient(t).Ping(context.Background()))
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// Bookkeeping of imports, kept in the database so that an interrupted
+// import resumes where its last batch was committed. import_id maps the
+// fullnames of the dump, such as t3_abc or u/name, to the new IDs, and
+// import_orphan holds comments imported before their parent.
+const importSchema = `
+CREATE TABLE IF NOT EXISTS "import_id" ("fullname" text, "id" integer NOT NULL, PRIMARY KEY (fullname));
+CREATE TABLE IF NOT EXISTS "import_progress" ("file" text, "line" integer NOT NULL, PRIMARY KEY (file));
+CREATE TABLE IF NOT EXISTS "import_orphan" ("commentID" integer, "parent" text NOT NULL, PRIMARY KEY (commentID));
+`
+
+// Submission of a Pushshift or Reddit API dump, with the fields imported
+type dumpSubmission struct {
+	ID                string   `json:"id"`
+	Title             string   `json:"title"`
+	Selftext          string   `json:"selftext"`
+	URL               string   `json:"url"`
+	Subreddit         string   `json:"subreddit"`
+	SubredditID       string   `json:"subreddit_id"`
+	SubredditType     string   `json:"subreddit_type"`
+	Author            string   `json:"author"`
+	Score             int32    `json:"score"`
+	CreatedUTC        unixTime `json:"created_utc"`
+	IsVideo           bool     `json:"is_video"`
+	PostHint          string   `json:"post_hint"`
+	Locked            bool     `json:"locked"`
+	RemovedByCategory *string  `json:"removed_by_category"`
+	Media             struct {
+		RedditVideo struct {
+			FallbackURL string `json:"fallback_url"`
+		} `json:"reddit_video"`
+	} `json:"media"`
+}
+
+// Comment of a Pushshift or Reddit API dump, with the fields imported
+type dumpComment struct {
+	ID         string   `json:"id"`
+	Body       string   `json:"body"`
+	Author     string   `json:"author"`
+	ParentID   string   `json:"parent_id"`
+	Score      int32    `json:"score"`
+	CreatedUTC unixTime `json:"created_utc"`
+	Locked     bool     `json:"locked"`
+}
+
+// Seconds since the epoch, which dumps write as numbers or strings
+type unixTime int64
+
+func (t *unixTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid created_utc %s", data)
+	}
+	*t = unixTime(seconds)
+	return nil
+}
+
+func (t unixTime) date() *date.Date {
+	if t == 0 {
+		return nil
+	}
+	d := time.Unix(int64(t), 0).UTC()
+	return &date.Date{Year: int32(d.Year()), Month: int32(d.Month()), Day: int32(d.Day())}
+}
+
+// Import the submissions, then the comments, of NDJSON dumps
+func runImport(ctx context.Context, s Storage, args []string) error {
+	fs := commandFlags("import")
+	submissions := fs.String("submissions", "", "The NDJSON file of submissions")
+	comments := fs.String("comments", "", "The NDJSON file of comments")
+	batch := fs.Int("batch", 1000, "The lines imported per transaction")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	if *submissions == "" && *comments == "" || *batch <= 0 {
+		return errUsage
+	}
+
+	c, ok := s.(*SQLClient)
+	if !ok {
+		return errors.New("import needs the sqlite storage, restore an export of it into others")
+	}
+	if err := c.CreateSchema(ctx); err != nil {
+		return err
+	}
+	if _, err := c.db.ExecContext(ctx, importSchema); err != nil {
+		return err
+	}
+	imp := &importer{db: c.db, batch: *batch}
+	if *submissions != "" {
+		if err := imp.importFile(ctx, *submissions, imp.submission); err != nil {
+			return err
+		}
+	}
+	if *comments != "" {
+		if err := imp.importFile(ctx, *comments, imp.comment); err != nil {
+			return err
+		}
+	}
+	orphans, err := imp.adoptOrphans(ctx)
+	if err != nil {
+		return err
+	}
+	if orphans > 0 {
+		slog.Warn("Comments left without a parent, which is missing from the dumps", slog.Int("comments", orphans))
+	}
+	return nil
+}
+
+type importer struct {
+	db    *sql.DB
+	batch int
+}
+
+// Import the lines of a file after those already imported, committing
+// every batch with the progress
+func (imp *importer) importFile(ctx context.Context, file string, importLine func(context.Context, *sql.Tx, []byte) error) error {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var done int
+	err = imp.db.QueryRowContext(ctx, "SELECT line FROM import_progress WHERE file = (?)", path).Scan(&done)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if done > 0 {
+		slog.Info("Resuming import", slog.String("file", file), slog.Int("line", done))
+	}
+
+	r := bufio.NewReader(f)
+	start := time.Now()
+	line := 0
+	for eof := false; !eof; {
+		tx, err := imp.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		imported := 0
+		for imported < imp.batch {
+			data, err := r.ReadBytes('\n')
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if len(data) == 0 {
+				break
+			}
+			line++
+			if line <= done {
+				continue
+			}
+			data = []byte(strings.TrimSpace(string(data)))
+			if len(data) > 0 {
+				if err := importLine(ctx, tx, data); err != nil {
+					tx.Rollback()
+					return fmt.Errorf("%s:%d: %w", file, line, err)
+				}
+			}
+			imported++
+		}
+		if imported == 0 {
+			tx.Rollback()
+			break
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO import_progress (file, line) VALUES (?, ?) ON CONFLICT (file) DO UPDATE SET line = excluded.line", path, line)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		slog.Info("Imported", slog.String("file", file), slog.Int("line", line),
+			slog.Float64("lines_per_second", float64(line-done)/time.Since(start).Seconds()))
+	}
+	return nil
+}
+
+// ID an earlier line of the dumps was imported as, or 0
+func lookupID(ctx context.Context, tx *sql.Tx, fullname string) (int, error) {
+	var id int
+	err := tx.QueryRowContext(ctx, "SELECT id FROM import_id WHERE fullname = (?)", fullname).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
+
+// Insert a row and remember the ID it got as that of fullname
+func insertImported(ctx context.Context, tx *sql.Tx, fullname string, query string, args ...any) (int, error) {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO import_id (fullname, id) VALUES (?, ?)", fullname, id)
+	return int(id), err
+}
+
+// ID of the author, created on its first appearance, or nil when deleted
+func (imp *importer) author(ctx context.Context, tx *sql.Tx, name string) (any, error) {
+	if name == "" || name == "[deleted]" {
+		return nil, nil
+	}
+	id, err := lookupID(ctx, tx, "u/"+name)
+	if err != nil || id != 0 {
+		return id, err
+	}
+	return insertImported(ctx, tx, "u/"+name, `INSERT INTO "user" DEFAULT VALUES`)
+}
+
+// ID of the subreddit of a submission, created on its first appearance
+func (imp *importer) subReddit(ctx context.Context, tx *sql.Tx, s *dumpSubmission) (int, error) {
+	fullname := s.SubredditID
+	if fullname == "" {
+		fullname = "r/" + s.Subreddit
+	}
+	id, err := lookupID(ctx, tx, fullname)
+	if err != nil || id != 0 {
+		return id, err
+	}
+	state := pb.SubRedditState_PUBLIC
+	if s.SubredditType == "private" {
+		state = pb.SubRedditState_PRIVATE
+	}
+	return insertImported(ctx, tx, fullname, "INSERT INTO subreddit (name, state, tags) VALUES (?, ?, ?)", "r/"+s.Subreddit, state, "")
+}
+
+func (imp *importer) submission(ctx context.Context, tx *sql.Tx, data []byte) error {
+	var s dumpSubmission
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s.ID == "" {
+		return errors.New("submission without an id")
+	}
+	fullname := "t3_" + s.ID
+	if id, err := lookupID(ctx, tx, fullname); err != nil || id != 0 {
+		// Imported by an earlier run
+		return err
+	}
+
+	subRedditID, err := imp.subReddit(ctx, tx, &s)
+	if err != nil {
+		return err
+	}
+	authorID, err := imp.author(ctx, tx, s.Author)
+	if err != nil {
+		return err
+	}
+	var videoURL, imageURL any
+	if s.IsVideo {
+		videoURL = s.URL
+		if s.Media.RedditVideo.FallbackURL != "" {
+			videoURL = s.Media.RedditVideo.FallbackURL
+		}
+	} else if s.PostHint == "image" {
+		imageURL = s.URL
+	}
+	state := pb.PostState_NORMAL_POST
+	if s.RemovedByCategory != nil || s.Selftext == "[removed]" || s.Selftext == "[deleted]" {
+		state = pb.PostState_HIDDEN_POST
+	} else if s.Locked {
+		state = pb.PostState_LOCKED_POST
+	}
+	_, err = insertImported(ctx, tx, fullname,
+		"INSERT INTO post (title, content, subRedditID, videoURL, imageURL, authorID, score, state, publicationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		s.Title, s.Selftext, subRedditID, videoURL, imageURL, authorID, s.Score, state, dateValue(s.CreatedUTC.date()))
+	return err
+}
+
+// Content type and ID of the parent of a comment, whose ID is 0 when the
+// parent is not imported yet
+func parentOf(ctx context.Context, tx *sql.Tx, fullname string) (pb.ContentType, int, error) {
+	var parent pb.ContentType
+	switch {
+	case strings.HasPrefix(fullname, "t3_"):
+		parent = pb.ContentType_POST
+	case strings.HasPrefix(fullname, "t1_"):
+		parent = pb.ContentType_COMMENT
+	default:
+		return 0, 0, fmt.Errorf("invalid parent_id %q", fullname)
+	}
+	id, err := lookupID(ctx, tx, fullname)
+	return parent, id, err
+}
+
+func (imp *importer) comment(ctx context.Context, tx *sql.Tx, data []byte) error {
+	var c dumpComment
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	if c.ID == "" {
+		return errors.New("comment without an id")
+	}
+	fullname := "t1_" + c.ID
+	if id, err := lookupID(ctx, tx, fullname); err != nil || id != 0 {
+		// Imported by an earlier run
+		return err
+	}
+
+	parent, parentID, err := parentOf(ctx, tx, c.ParentID)
+	if err != nil {
+		return err
+	}
+	authorID, err := imp.author(ctx, tx, c.Author)
+	if err != nil {
+		return err
+	}
+	state := pb.CommentState_NORMAL_COMMENT
+	if c.Locked {
+		state = pb.CommentState_LOCKED_COMMENT
+	}
+	id, err := insertImported(ctx, tx, fullname,
+		"INSERT INTO comment (content, authorID, score, state, publicationDate, parent, parentID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		c.Body, authorID, c.Score, state, dateValue(c.CreatedUTC.date()), parent, parentID)
+	if err != nil || parentID != 0 {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO import_orphan (commentID, parent) VALUES (?, ?)", id, c.ParentID)
+	return err
+}
+
+// Link the comments imported before their parents, returning how many
+// parents are still missing
+func (imp *importer) adoptOrphans(ctx context.Context) (int, error) {
+	tx, err := imp.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		"UPDATE comment SET parentID = (SELECT i.id FROM import_orphan o JOIN import_id i ON i.fullname = o.parent WHERE o.commentID = comment.id) WHERE id IN (SELECT o.commentID FROM import_orphan o JOIN import_id i ON i.fullname = o.parent)")
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM import_orphan WHERE parent IN (SELECT fullname FROM import_id)")
+	if err != nil {
+		return 0, err
+	}
+	var orphans int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM import_orphan").Scan(&orphans); err != nil {
+		return 0, err
+	}
+	return orphans, tx.Commit()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// Open an empty database that is removed when the test ends
+func newEmptySQLClient(t testing.TB) *SQLClient {
+	client, err := NewSQLClient(filepath.Join(t.TempDir(), "reddit.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.db.Close() })
+	return client
+}
+
+func writeLines(t *testing.T, name string, lines ...string) string {
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	return file
+}
+
+var testSubmissions = []string{
+	`{"id": "a1", "title": "Cat", "selftext": "Meow", "subreddit": "aww", "subreddit_id": "t5_2qh1o", "author": "alice", "score": 12, "created_utc": 1700000000}`,
+	`{"id": "a2", "title": "Dog", "url": "https://i.redd.it/dog.jpg", "post_hint": "image", "subreddit": "aww", "subreddit_id": "t5_2qh1o", "author": "[deleted]", "score": 3, "created_utc": "1700086400", "locked": true}`,
+	`{"id": "a3", "title": "Gone", "selftext": "[removed]", "subreddit": "secret", "subreddit_type": "private", "author": "bob", "removed_by_category": "moderator"}`,
+}
+
+var testComments = []string{
+	`{"id": "c1", "body": "Cute", "author": "bob", "parent_id": "t3_a1", "score": 5, "created_utc": 1700000100}`,
+	// Replies to a comment further down
+	`{"id": "c2", "body": "Agreed", "author": "alice", "parent_id": "t1_c3", "score": 1}`,
+	`{"id": "c3", "body": "Very", "author": "carol", "parent_id": "t1_c1", "score": 2}`,
+	// Under a post missing from the dump
+	`{"id": "c4", "body": "Lost", "author": "carol", "parent_id": "t3_zz"}`,
+}
+
+func TestImport(t *testing.T) {
+	client := newEmptySQLClient(t)
+	ctx := context.Background()
+	submissions := writeLines(t, "RS.ndjson", testSubmissions...)
+	comments := writeLines(t, "RC.ndjson", testComments...)
+
+	require.NoError(t, runCommand(ctx, client, []string{"import", "-submissions", submissions, "-comments", comments, "-batch", "2"}))
+
+	subReddits, err := client.ListSubReddits(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, subReddits, 2)
+	assert.Equal(t, "r/aww", subReddits[0].GetName())
+	assert.Equal(t, pb.SubRedditState_PRIVATE, subReddits[1].GetState())
+
+	cat, err := client.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Meow", cat.GetContent())
+	assert.Equal(t, int32(12), cat.GetScore())
+	assert.Equal(t, &date.Date{Year: 2023, Month: 11, Day: 14}, cat.GetPublicationDate())
+	dog, err := client.GetPost(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "https://i.redd.it/dog.jpg", dog.GetImageURL())
+	assert.Equal(t, pb.PostState_LOCKED_POST, dog.GetState())
+	assert.Nil(t, dog.GetAuthor(), "deleted author")
+	gone, err := client.GetPost(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, pb.PostState_HIDDEN_POST, gone.GetState())
+	assert.Equal(t, cat.GetAuthor().GetId()+1, gone.GetAuthor().GetId())
+
+	// The parents are linked by their new IDs, including c2 imported before c3
+	top, err := client.GetTopComments(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "Cute", top[0].GetContent())
+	assert.Equal(t, gone.GetAuthor().GetId(), top[0].GetAuthor().GetId())
+	replies, err := client.ExpandCommentBranch(ctx, int(top[0].GetId()), 10)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+	assert.Equal(t, "Very", replies[0].GetContent())
+	require.Len(t, replies[0].GetChildren(), 1)
+	assert.Equal(t, "Agreed", replies[0].GetChildren()[0].GetContent())
+
+	var orphans int
+	require.NoError(t, client.db.QueryRow("SELECT COUNT(*) FROM import_orphan").Scan(&orphans))
+	assert.Equal(t, 1, orphans)
+}
+
+func TestImportResume(t *testing.T) {
+	client := newEmptySQLClient(t)
+	ctx := context.Background()
+	submissions := writeLines(t, "RS.ndjson", testSubmissions...)
+	broken := append(append([]string{}, testComments[:3]...), `{"id": "c4", "body": `)
+	comments := writeLines(t, "RC.ndjson", broken...)
+
+	err := runCommand(ctx, client, []string{"import", "-submissions", submissions, "-comments", comments, "-batch", "2"})
+	require.ErrorContains(t, err, "RC.ndjson:4: ")
+	count := func() (n int) {
+		require.NoError(t, client.db.QueryRow("SELECT COUNT(*) FROM comment").Scan(&n))
+		return n
+	}
+	assert.Equal(t, 2, count(), "the failed batch is rolled back")
+
+	// Rerunning after fixing the dump imports the rest once
+	require.NoError(t, os.WriteFile(comments, []byte(strings.Join(testComments, "\n")), 0o600))
+	require.NoError(t, runCommand(ctx, client, []string{"import", "-submissions", submissions, "-comments", comments}))
+	assert.Equal(t, 4, count())
+	posts, err := client.ListPosts(ctx, 0, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2, "besides the hidden post")
+
+	assert.ErrorIs(t, runCommand(ctx, client, []string{"import"}), errUsage)
+}
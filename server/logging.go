@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	requestIDKey = "x-request-id"
+)
+
+type loggerKey struct{}
+
+// Build the process logger writing text or JSON records at the given level
+func newLogger(w io.Writer, format string, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// Return the request scoped logger installed by the logging interceptors
+func loggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Return the request ID sent by the caller, or generate a new one
+func requestID(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDKey); len(values) > 0 {
+		return values[0]
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// Format a message for debug logging, cut to at most limit bytes
+func truncatePayload(msg any, limit int) string {
+	s := fmt.Sprint(msg)
+	if limit > 0 && len(s) > limit {
+		return s[:limit] + fmt.Sprintf("...(%d bytes truncated)", len(s)-limit)
+	}
+	return s
+}
+
+// Level a finished call is logged at, based on its status code
+func codeToLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.Unauthenticated:
+		return slog.LevelInfo
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// Attach the per-call fields to the logger and echo the request ID to the caller
+func callLogger(ctx context.Context, logger *slog.Logger, method string) (context.Context, *slog.Logger) {
+	id := requestID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	logger = logger.With(
+		slog.String("method", strings.TrimPrefix(method, "/")),
+		slog.String("peer", peerAddr(ctx)),
+		slog.String("request_id", id),
+	)
+	return context.WithValue(ctx, loggerKey{}, logger), logger
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []slog.Attr{
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", code.String()),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	logger.LogAttrs(ctx, codeToLevel(code), "Finished call", attrs...)
+}
+
+// Log every unary call, with payloads at debug level only
+func unaryLoggingInterceptor(logger *slog.Logger, payloadLimit int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, logger := callLogger(ctx, logger, info.FullMethod)
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Debug("Received request", slog.String("payload", truncatePayload(req, payloadLimit)))
+		}
+
+		resp, err := handler(ctx, req)
+
+		if err == nil && logger.Enabled(ctx, slog.LevelDebug) {
+			logger.Debug("Sending response", slog.String("payload", truncatePayload(resp, payloadLimit)))
+		}
+		logCall(ctx, logger, start, err)
+		return resp, err
+	}
+}
+
+// Server stream that logs each message at debug level
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx          context.Context
+	logger       *slog.Logger
+	payloadLimit int
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *loggingServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.logger.Enabled(s.ctx, slog.LevelDebug) {
+		s.logger.Debug("Received message", slog.String("payload", truncatePayload(m, s.payloadLimit)))
+	}
+	return err
+}
+
+func (s *loggingServerStream) SendMsg(m any) error {
+	if s.logger.Enabled(s.ctx, slog.LevelDebug) {
+		s.logger.Debug("Sending message", slog.String("payload", truncatePayload(m, s.payloadLimit)))
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// Log every streaming call, with messages at debug level only
+func streamLoggingInterceptor(logger *slog.Logger, payloadLimit int) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, logger := callLogger(ss.Context(), logger, info.FullMethod)
+		logger.Debug("Started stream")
+
+		err := handler(srv, &loggingServerStream{ServerStream: ss, ctx: ctx, logger: logger, payloadLimit: payloadLimit})
+
+		logCall(ctx, logger, start, err)
+		return err
+	}
+}
+
+// Log an error and exit
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
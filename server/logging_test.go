@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Run a unary call through the logging interceptor and return the decoded records
+func runLoggedCall(t *testing.T, level string, req any, handlerErr error) []map[string]any {
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, "json", level)
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDKey, "req-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/reddit.Reddit/GetPost"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &pb.GetPostResponse{}, handlerErr
+	}
+	unaryLoggingInterceptor(logger, 16)(ctx, req, info, handler)
+
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestUnaryLoggingInterceptor(t *testing.T) {
+	records := runLoggedCall(t, "info", &pb.GetPostRequest{PostID: 1}, status.Error(codes.NotFound, "not found"))
+
+	// Payloads are not logged at info level
+	require.Len(t, records, 1)
+	assert.Equal(t, "reddit.Reddit/GetPost", records[0]["method"])
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "NotFound", records[0]["code"])
+	assert.Contains(t, records[0], "duration")
+}
+
+func TestUnaryLoggingInterceptorDebugPayload(t *testing.T) {
+	request := &pb.CreatePostRequest{Post: &pb.Post{Title: strings.Repeat("x", 100)}}
+	records := runLoggedCall(t, "debug", request, nil)
+
+	require.Len(t, records, 3)
+	payload := records[0]["payload"].(string)
+	assert.Less(t, len(payload), 100)
+	assert.Contains(t, payload, "bytes truncated")
+	assert.Equal(t, "OK", records[2]["code"])
+}
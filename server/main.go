@@ -2,198 +2,490 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/fatih/color"
 	_ "github.com/mattn/go-sqlite3"
 	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxBatchSize    = 100
+
+	// Longest HTTP clients may take to send request headers. Streams stay
+	// open, so there is no timeout on the rest of the request or response.
+	readHeaderTimeout = 10 * time.Second
 )
 
 var (
-	addr = flag.String("addr", "localhost", "the address to connect to")
-	port = flag.Int("port", 50051, "The server port")
+	addr   = flag.String("addr", "localhost", "the address to connect to")
+	port   = flag.Int("port", 50051, "The server port")
+	dbFile = flag.String("db", db_file, "The SQLite database file")
+
+	storageBackend = flag.String("storage", "sqlite", "The storage backend: sqlite or postgres")
+	postgresURL    = flag.String("postgres_url", "postgres://localhost:5432/reddit", "The PostgreSQL connection URL used by the postgres storage")
+
+	cacheSize = flag.Int("cache_size", 10000, "The maximum posts and top comment listings cached in memory, 0 to disable the cache")
+	cacheTTL  = flag.Duration("cache_ttl", time.Second, "The longest a cached read is served, which bounds how stale its scores are after writes by other servers")
+
+	certFile     = flag.String("cert_file", "", "The TLS cert file, enables TLS when set")
+	keyFile      = flag.String("key_file", "", "The TLS key file")
+	clientCAFile = flag.String("client_ca_file", "", "The CA file to verify client certs against, enables mutual TLS when set")
+
+	logLevel        = flag.String("log_level", "info", "The minimum log level: debug, info, warn or error")
+	logFormat       = flag.String("log_format", "text", "The log output format: text or json")
+	logPayloadLimit = flag.Int("log_payload_limit", 1024, "The maximum bytes of a payload logged at debug level, 0 for no limit")
+
+	metricsAddr = flag.String("metrics_addr", "", "The address to serve Prometheus metrics on, disabled when empty")
+
+	traceExporter = flag.String("trace_exporter", "none", "The OpenTelemetry trace exporter: none, stdout or otlp")
+	otlpEndpoint  = flag.String("otlp_endpoint", "localhost:4317", "The OTLP gRPC collector address used by the otlp trace exporter")
+
+	healthInterval   = flag.Duration("health_interval", 5*time.Second, "How often the database is pinged to update the health status")
+	enableReflection = flag.Bool("reflection", false, "Register the gRPC server reflection service")
+
+	rateLimits       = flag.String("rate_limits", "CreatePost=1:5,VotePost=5:20,CreateComment=1:5,VoteComment=5:20,MonitorUpdates=1:5,WatchUpdates=1:5", "Per caller token bucket limits as Method=rate:burst, with * for all other methods")
+	maxSubscriptions = flag.Int("max_subscriptions", 100, "The maximum contents a caller can monitor across its update streams, 0 for no limit")
+
+	httpAddr    = flag.String("http_addr", "", "The address to serve the HTTP/JSON gateway and gRPC-Web on, disabled when empty")
+	corsOrigins = flag.String("cors_origins", "", "Comma separated origins browsers may call the HTTP address from, with * letting any origin call the HTTP/JSON gateway without credentials")
 )
 
 type gRPCserver struct {
 	pb.UnimplementedRedditServer
-	sqlClient *SQLClient
+	storage       Storage
+	subscriptions *subscriptionLimiter
 }
 
 // Create a post
 func (s *gRPCserver) CreatePost(ctx context.Context, in *pb.CreatePostRequest) (*pb.CreatePostResponse, error) {
-	log.Print(color.YellowString("[CreatePost] Received: %v", in))
-
 	// Insert the post into the database
-	id, err := s.sqlClient.CreatePost(in.GetPost())
+	id, err := s.storage.CreatePost(ctx, in.GetPost())
 	if err != nil {
-		log.Fatal(color.RedString("[CreatePost] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	// Get the post from the database
-	post, err := s.sqlClient.GetPost(id)
+	post, err := s.storage.GetPost(ctx, id)
 	if err != nil {
-		log.Fatal(color.RedString("[CreatePost] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.CreatePostResponse{Post: post}
-	log.Print(color.GreenString("[CreatePost] Reponse: %v", response))
 	return response, nil
 }
 
 // Upvote or downvote a Post
 func (s *gRPCserver) VotePost(ctx context.Context, in *pb.VotePostRequest) (*pb.VotePostResponse, error) {
-	log.Print(color.YellowString("[VotePost] Received: %v", in))
-
 	// Increment/Decrement the score of the post
-	newScore, err := s.sqlClient.VotePost(int(in.GetPostID()), in.GetUpvote())
+	newScore, err := s.storage.VotePost(ctx, int(in.GetPostID()), in.GetUpvote())
 	if err != nil {
-		log.Fatal(color.RedString("[VotePost] DB error: %v", err))
+		return nil, dbError(err)
 	}
+	observeVote("post", in.GetUpvote())
 
 	response := &pb.VotePostResponse{Score: int32(newScore)}
-	log.Print(color.GreenString("[VotePost] Reponse: %v", response))
 	return response, nil
 }
 
 // Retrieve Post content
 func (s *gRPCserver) GetPost(ctx context.Context, in *pb.GetPostRequest) (*pb.GetPostResponse, error) {
-	log.Print(color.YellowString("[GetPost] Received: %v", in))
 	id := in.GetPostID()
 
 	// Get the post from the database
-	post, err := s.sqlClient.GetPost(int(id))
+	post, err := s.storage.GetPost(ctx, int(id))
 	if err != nil {
-		log.Fatal(color.RedString("[GetPost] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.GetPostResponse{Post: post}
-	log.Print(color.GreenString("[GetPost] Reponse: %v", response))
 	return response, nil
 }
 
 // Create a Comment
 func (s *gRPCserver) CreateComment(ctx context.Context, in *pb.CreateCommentRequest) (*pb.CreateCommentResponse, error) {
-	log.Print(color.YellowString("[CreateComment] Received: %v", in))
-
 	// Insert the comment into the database
-	id, err := s.sqlClient.CreateComment(in.GetComment())
+	id, err := s.storage.CreateComment(ctx, in.GetComment())
 	if err != nil {
-		log.Fatal(color.RedString("[CreateComment] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	// Get the comment from the database
-	comment, err := s.sqlClient.GetComment(id)
+	comment, err := s.storage.GetComment(ctx, id)
 	if err != nil {
-		log.Fatal(color.RedString("[CreateComment] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.CreateCommentResponse{Comment: comment}
-	log.Print(color.GreenString("[CreateComment] Reponse: %v", response))
 	return response, nil
 }
 
 // Upvote or downvote a Comment
 func (s *gRPCserver) VoteComment(ctx context.Context, in *pb.VoteCommentRequest) (*pb.VoteCommentResponse, error) {
-	log.Print(color.YellowString("[VoteComment] Received: %v", in))
-
 	// Increment/Decrement the score of the post
-	newScore, err := s.sqlClient.VoteComment(int(in.GetCommentID()), in.GetUpvote())
+	newScore, err := s.storage.VoteComment(ctx, int(in.GetCommentID()), in.GetUpvote())
 	if err != nil {
-		log.Fatal(color.RedString("[VoteComment] DB error: %v", err))
+		return nil, dbError(err)
 	}
+	observeVote("comment", in.GetUpvote())
 
 	response := &pb.VoteCommentResponse{Score: int32(newScore)}
-	log.Print(color.GreenString("[VoteComment] Reponse: %v", response))
 	return response, nil
 }
 
 // Retrieve a Comment
 func (s *gRPCserver) GetComment(ctx context.Context, in *pb.GetCommentRequest) (*pb.GetCommentResponse, error) {
-	log.Print(color.YellowString("[GetComment] Received: %v", in))
 	id := in.GetCommentID()
 
 	// Get the comment from the database
-	comment, err := s.sqlClient.GetComment(int(id))
+	comment, err := s.storage.GetComment(ctx, int(id))
 	if err != nil {
-		log.Fatal(color.RedString("[GetComment] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.GetCommentResponse{Comment: comment}
-	log.Print(color.GreenString("[GetComment] Reponse: %v", response))
 	return response, nil
 }
 
+// Retrieve several Posts at once, with a result for each ID in order
+func (s *gRPCserver) BatchGetPosts(ctx context.Context, in *pb.BatchGetPostsRequest) (*pb.BatchGetPostsResponse, error) {
+	ids, err := batchIDs(in.GetPostIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the posts from the database in one query
+	posts, err := s.storage.GetPosts(ctx, ids)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	byID := make(map[int32]*pb.Post, len(posts))
+	for _, post := range posts {
+		byID[post.GetId()] = post
+	}
+
+	response := &pb.BatchGetPostsResponse{Results: make([]*pb.PostResult, len(in.GetPostIDs()))}
+	for i, id := range in.GetPostIDs() {
+		post, ok := byID[id]
+		response.Results[i] = &pb.PostResult{PostID: id, Post: post, NotFound: !ok}
+	}
+	return response, nil
+}
+
+// Retrieve several Comments at once, with a result for each ID in order
+func (s *gRPCserver) BatchGetComments(ctx context.Context, in *pb.BatchGetCommentsRequest) (*pb.BatchGetCommentsResponse, error) {
+	ids, err := batchIDs(in.GetCommentIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the comments from the database in one query
+	comments, err := s.storage.GetComments(ctx, ids)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	byID := make(map[int32]*pb.Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.GetId()] = comment
+	}
+
+	response := &pb.BatchGetCommentsResponse{Results: make([]*pb.CommentResult, len(in.GetCommentIDs()))}
+	for i, id := range in.GetCommentIDs() {
+		comment, ok := byID[id]
+		response.Results[i] = &pb.CommentResult{CommentID: id, Comment: comment, NotFound: !ok}
+	}
+	return response, nil
+}
+
+// Distinct IDs of a batch request of at most maxBatchSize IDs
+func batchIDs(requested []int32) ([]int, error) {
+	if len(requested) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d IDs can be requested at once, got %d", maxBatchSize, len(requested))
+	}
+	seen := make(map[int32]bool, len(requested))
+	ids := make([]int, 0, len(requested))
+	for _, id := range requested {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, int(id))
+		}
+	}
+	return ids, nil
+}
+
 // Retrieving a list of N most upvoted comments under a post
 func (s *gRPCserver) GetTopComments(ctx context.Context, in *pb.GetTopCommentsRequest) (*pb.GetTopCommentsResponse, error) {
-	log.Print(color.YellowString("[GetTopComments] Received: %v", in))
-
 	// Get the comments from the database
-	comments, err := s.sqlClient.GetTopComments(int(in.GetPostID()), int(in.GetQuantity()))
+	comments, err := s.storage.GetTopComments(ctx, int(in.GetPostID()), int(in.GetQuantity()))
 	if err != nil {
-		log.Fatal(color.RedString("[GetTopComments] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.GetTopCommentsResponse{Comments: comments}
-	log.Print(color.GreenString("[GetTopComments] Reponse: %v", response))
 	return response, nil
 }
 
 // Expand a comment branch
 func (s *gRPCserver) ExpandCommentBranch(ctx context.Context, in *pb.ExpandCommentBranchRequest) (*pb.ExpandCommentBranchResponse, error) {
-	log.Print(color.YellowString("[ExpandCommentBranch] Received: %v", in))
-
 	// Get the comments from the database
-	comments, err := s.sqlClient.ExpandCommentBranch(int(in.GetCommentID()), int(in.GetQuantity()))
+	comments, err := s.storage.ExpandCommentBranch(ctx, int(in.GetCommentID()), int(in.GetQuantity()))
 	if err != nil {
-		log.Fatal(color.RedString("[ExpandCommentBranch] DB error: %v", err))
+		return nil, dbError(err)
 	}
 
 	response := &pb.ExpandCommentBranchResponse{Comments: comments}
-	log.Print(color.GreenString("[ExpandCommentBranch] Reponse: %v", response))
 	return response, nil
 }
 
+// List the posts of a subreddit, or of every subreddit
+func (s *gRPCserver) ListPosts(ctx context.Context, in *pb.ListPostsRequest) (*pb.ListPostsResponse, error) {
+	afterID, err := parsePageToken(in.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	size := pageSize(in.GetPageSize())
+
+	// Get one more post than asked for to know whether another page follows
+	posts, err := s.storage.ListPosts(ctx, int(in.GetSubRedditID()), afterID, size+1)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	response := &pb.ListPostsResponse{Posts: posts}
+	if len(posts) > size {
+		response.Posts = posts[:size]
+		response.NextPageToken = strconv.Itoa(int(posts[size-1].GetId()))
+	}
+	return response, nil
+}
+
+// Search posts by title and content
+func (s *gRPCserver) SearchPosts(ctx context.Context, in *pb.SearchPostsRequest) (*pb.SearchPostsResponse, error) {
+	if strings.TrimSpace(in.GetQuery()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty search query")
+	}
+	posts, err := s.storage.SearchPosts(ctx, in.GetQuery(), int(in.GetSubRedditID()), pageSize(in.GetLimit()))
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	response := &pb.SearchPostsResponse{Posts: posts}
+	return response, nil
+}
+
+// Retrieve a SubReddit
+func (s *gRPCserver) GetSubReddit(ctx context.Context, in *pb.GetSubRedditRequest) (*pb.GetSubRedditResponse, error) {
+	subReddit, err := s.storage.GetSubReddit(ctx, int(in.GetSubRedditID()))
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	response := &pb.GetSubRedditResponse{SubReddit: subReddit}
+	return response, nil
+}
+
+// List the subreddits that are not hidden
+func (s *gRPCserver) ListSubReddits(ctx context.Context, in *pb.ListSubRedditsRequest) (*pb.ListSubRedditsResponse, error) {
+	afterID, err := parsePageToken(in.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	size := pageSize(in.GetPageSize())
+
+	subReddits, err := s.storage.ListSubReddits(ctx, afterID, size+1)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	response := &pb.ListSubRedditsResponse{SubReddits: subReddits}
+	if len(subReddits) > size {
+		response.SubReddits = subReddits[:size]
+		response.NextPageToken = strconv.Itoa(int(subReddits[size-1].GetId()))
+	}
+	return response, nil
+}
+
+// Page size of a list request, defaultPageSize when unset and at most maxPageSize
+func pageSize(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// ID of the last item of the previous page, 0 for the first page
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(token)
+	if err != nil || id < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+	return id, nil
+}
+
 // Monitor updates to posts and comments
 func (s *gRPCserver) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error {
+	return s.monitor(stream.Context(), stream.Recv, stream.Send)
+}
+
+// Watch updates to a fixed set of posts and comments
+func (s *gRPCserver) WatchUpdates(in *pb.WatchUpdatesRequest, stream pb.Reddit_WatchUpdatesServer) error {
+	subscriptions := in.GetSubscriptions()
+	recv := func() (*pb.MonitorUpdatesRequest, error) {
+		if len(subscriptions) == 0 {
+			return nil, io.EOF
+		}
+		next := subscriptions[0]
+		subscriptions = subscriptions[1:]
+		return next, nil
+	}
+	return s.monitor(stream.Context(), recv, stream.Send)
+}
+
+// Send the scores of the contents subscribed through recv every 2 seconds,
+// until ctx is done or sending fails
+func (s *gRPCserver) monitor(ctx context.Context, recv func() (*pb.MonitorUpdatesRequest, error), send func(*pb.MonitorUpdatesResponse) error) error {
+	caller := callerID(ctx)
+	var mu sync.Mutex
+	closed := false
 	monitorPostList := []int{}
 	monitorCommentList := []int{}
 
+	monitorStreams.Inc()
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		count := len(monitorPostList) + len(monitorCommentList)
+		s.subscriptions.release(caller, count)
+		monitorSubscriptions.Sub(float64(count))
+		monitorStreams.Dec()
+	}()
+
+	// Stop monitoring a content, with mu held
+	unsubscribe := func(contentType pb.ContentType, id int) {
+		removed := false
+		switch contentType {
+		case pb.ContentType_POST:
+			monitorPostList, removed = removeID(monitorPostList, id)
+		case pb.ContentType_COMMENT:
+			monitorCommentList, removed = removeID(monitorCommentList, id)
+		}
+		if removed {
+			s.subscriptions.release(caller, 1)
+			monitorSubscriptions.Dec()
+		}
+	}
+
+	// Tell the client a content does not exist and stop monitoring it,
+	// keeping the stream open for the others
+	notFound := func(contentType pb.ContentType, id int) error {
+		mu.Lock()
+		unsubscribe(contentType, id)
+		mu.Unlock()
+		return send(&pb.MonitorUpdatesResponse{ContentType: contentType, ContentID: int32(id), NotFound: true})
+	}
+
 	// Process client requests to add content to the list of monitored contents
+	errc := make(chan error, 1)
+	subscribed := make(chan struct{}, 1)
 	go func() {
 		for {
-			in, err := stream.Recv()
+			in, err := recv()
 			if err == io.EOF {
 				return
 			}
 			if err != nil {
-				log.Print(color.RedString("[MonitorUpdates] Error: %v", err))
+				loggerFromContext(ctx).Debug("Stopped receiving", slog.Any("error", err))
+				return
+			}
+
+			mu.Lock()
+			if closed {
+				mu.Unlock()
 				return
 			}
-			log.Print(color.YellowString("[MonitorUpdates] Received: %v", in))
+
+			// Remove the content from the list of monitored contents
+			if in.GetUnsubscribe() {
+				unsubscribe(in.GetContentType(), int(in.GetContentID()))
+				mu.Unlock()
+				continue
+			}
 
 			// Add the content to the list of monitored contents
+			if !s.subscriptions.acquire(caller) {
+				mu.Unlock()
+				errc <- status.Errorf(codes.ResourceExhausted, "subscription limit of %d reached", s.subscriptions.max)
+				return
+			}
 			switch in.GetContentType() {
 			case pb.ContentType_POST:
 				monitorPostList = append(monitorPostList, int(in.GetContentID()))
+				monitorSubscriptions.Inc()
 			case pb.ContentType_COMMENT:
 				monitorCommentList = append(monitorCommentList, int(in.GetContentID()))
+				monitorSubscriptions.Inc()
+			default:
+				s.subscriptions.release(caller, 1)
+			}
+			mu.Unlock()
+
+			// Send the current scores without waiting for the next round
+			select {
+			case subscribed <- struct{}{}:
+			default:
 			}
 		}
 	}()
 
 	// Send the updates
 	for {
+		mu.Lock()
+		postIDs := append([]int{}, monitorPostList...)
+		commentIDs := append([]int{}, monitorCommentList...)
+		mu.Unlock()
+
 		// Send the updates for the posts
-		for _, postID := range monitorPostList {
-			post, err := s.sqlClient.GetPost(postID)
-			if err != nil {
-				log.Fatal(color.RedString("[MonitorUpdates] DB error: %v", err))
+		for _, postID := range postIDs {
+			post, err := s.storage.GetPost(ctx, postID)
+			if errors.Is(err, sql.ErrNoRows) {
+				if err := notFound(pb.ContentType_POST, postID); err != nil {
+					return err
+				}
+				continue
+			} else if err != nil {
+				return dbError(err)
 			}
 
 			// Send the updates
@@ -202,19 +494,22 @@ func (s *gRPCserver) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error
 				ContentID:   int32(postID),
 				Score:       post.Score,
 			}
-			log.Print(color.GreenString("[MonitorUpdates] Response: %v", response))
-			err = stream.Send(response)
+			err = send(response)
 			if err != nil {
-				log.Print(color.RedString("[MonitorUpdates] Error: %v", err))
 				return err
 			}
 		}
 
 		// Send the updates for the comments
-		for _, commentID := range monitorCommentList {
-			comment, err := s.sqlClient.GetComment(commentID)
-			if err != nil {
-				log.Fatal(color.RedString("[MonitorUpdates] DB error: %v", err))
+		for _, commentID := range commentIDs {
+			comment, err := s.storage.GetComment(ctx, commentID)
+			if errors.Is(err, sql.ErrNoRows) {
+				if err := notFound(pb.ContentType_COMMENT, commentID); err != nil {
+					return err
+				}
+				continue
+			} else if err != nil {
+				return dbError(err)
 			}
 
 			// Send the updates
@@ -223,41 +518,183 @@ func (s *gRPCserver) MonitorUpdates(stream pb.Reddit_MonitorUpdatesServer) error
 				ContentID:   int32(commentID),
 				Score:       comment.Score,
 			}
-			log.Print(color.GreenString("[MonitorUpdates] Response: %v", response))
-			err = stream.Send(response)
+			err = send(response)
 			if err != nil {
-				log.Print(color.RedString("[MonitorUpdates] Error: %v", err))
 				return err
 			}
 		}
 
 		// Wait for 2 seconds before sending the updates again
-		time.Sleep(2 * time.Second)
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-subscribed:
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// Remove the first occurrence of id from ids
+func removeID(ids []int, id int) ([]int, bool) {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...), true
+		}
 	}
+	return ids, false
+}
+
+// gRPC server of the Reddit service, with tracing, logging, metrics and
+// rate limiting on every call
+func newGRPCServer(s *gRPCserver, logger *slog.Logger, payloadLimit int, limiter *rateLimiter, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			unaryLoggingInterceptor(logger, payloadLimit),
+			unaryMetricsInterceptor,
+			limiter.unaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			streamLoggingInterceptor(logger, payloadLimit),
+			streamMetricsInterceptor,
+			limiter.streamInterceptor,
+		),
+	}, opts...)
+	gs := grpc.NewServer(opts...)
+	pb.RegisterRedditServer(gs, s)
+	return gs
 }
 
 func main() {
 	// Parse the flags
+	flag.Usage = func() {
+		commandUsage()
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	// Set up logging
+	logger, err := newLogger(os.Stderr, *logFormat, *logLevel)
+	if err != nil {
+		fatal("Invalid logging flags", slog.Any("error", err))
+	}
+	slog.SetDefault(logger)
+
+	// Set up tracing
+	shutdownTracing, err := setupTracing(context.Background(), *traceExporter, *otlpEndpoint)
+	if err != nil {
+		fatal("Failed to set up tracing", slog.Any("error", err))
+	}
+	defer shutdownTracing(context.Background())
+
 	// Open the database
-	s := &gRPCserver{}
-	var err error
-	s.sqlClient, err = NewSQLClient()
+	s := &gRPCserver{subscriptions: newSubscriptionLimiter(*maxSubscriptions)}
+	s.storage, err = openStorage(*storageBackend, *dbFile, *postgresURL)
 	if err != nil {
-		log.Fatal(color.RedString("[Server] Error opening database: %v", err))
+		fatal("Error opening database", slog.Any("error", err))
+	}
+	defer s.storage.Close()
+	// PostgreSQL databases are migrated to the schema of this version
+	if _, ok := s.storage.(*PostgresClient); ok {
+		if err := s.storage.CreateSchema(context.Background()); err != nil {
+			fatal("Failed to migrate database", slog.Any("error", err))
+		}
+	}
+
+	// Run a maintenance command instead of serving
+	if flag.NArg() > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runCommand(ctx, s.storage, flag.Args()); err != nil {
+			fatal("Command failed", slog.String("command", flag.Arg(0)), slog.Any("error", err))
+		}
+		return
+	}
+	if *cacheSize > 0 {
+		s.storage = newCachedStorage(s.storage, *cacheSize, *cacheTTL)
 	}
 
 	// Launch the server
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", *addr, *port))
 	if err != nil {
-		log.Fatal(color.RedString("[Server] Failed to listen: %v", err))
+		fatal("Failed to listen", slog.Any("error", err))
 	}
 
-	gs := grpc.NewServer()
-	pb.RegisterRedditServer(gs, s)
-	log.Print(color.GreenString("[Server] Listening at %v", lis.Addr()))
+	// Launch the metrics listener
+	if *metricsAddr != "" {
+		go func() {
+			logger.Info("Serving metrics", slog.String("addr", *metricsAddr))
+			if err := serveMetrics(*metricsAddr); err != nil {
+				fatal("Failed to serve metrics", slog.Any("error", err))
+			}
+		}()
+	}
+
+	limits, err := parseRateLimits(*rateLimits)
+	if err != nil {
+		fatal("Invalid rate limits", slog.Any("error", err))
+	}
+	limiter := newRateLimiter(limits)
+
+	var opts []grpc.ServerOption
+	var tlsConfig *tls.Config
+	if *certFile != "" {
+		tlsConfig, err = newServerTLSConfig(*certFile, *keyFile, *clientCAFile)
+		if err != nil {
+			fatal("Failed to load TLS credentials", slog.Any("error", err))
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	gs := newGRPCServer(s, logger, *logPayloadLimit, limiter, opts...)
+
+	// Report health based on database reachability
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(gs, hs)
+	go watchHealth(context.Background(), hs, s.storage, *healthInterval)
+
+	if *enableReflection {
+		reflection.Register(gs)
+	}
+
+	// Launch the HTTP/JSON gateway and gRPC-Web
+	if *httpAddr != "" {
+		conn, err := dialInProcess(gs)
+		if err != nil {
+			fatal("Failed to connect the gateway", slog.Any("error", err))
+		}
+		handler := newHTTPHandler(gs, newGateway(conn), parseCORSOrigins(*corsOrigins))
+		httpServer := &http.Server{
+			Addr:              *httpAddr,
+			Handler:           handler,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		go func() {
+			logger.Info("Serving HTTP gateway and gRPC-Web", slog.String("addr", *httpAddr))
+			if tlsConfig != nil {
+				err = httpServer.ListenAndServeTLS("", "")
+			} else {
+				err = httpServer.ListenAndServe()
+			}
+			if err != nil {
+				fatal("Failed to serve HTTP gateway", slog.Any("error", err))
+			}
+		}()
+	}
+	logger.Info("Listening", slog.String("addr", lis.Addr().String()))
 	if err := gs.Serve(lis); err != nil {
-		log.Fatal(color.RedString("[Server] Failed to serve: %v", err))
+		fatal("Failed to serve", slog.Any("error", err))
+	}
+}
+
+// Convert a storage error into a gRPC status error
+func dbError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "not found")
 	}
+	return status.Errorf(codes.Internal, "database error: %v", err)
 }
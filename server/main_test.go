@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc"
+)
+
+func TestMonitorUpdatesUnsubscribe(t *testing.T) {
+	// A single subscription per caller, so the second one needs the first released
+	gs := grpc.NewServer()
+	pb.RegisterRedditServer(gs, &gRPCserver{storage: newTestSQLClient(t), subscriptions: newSubscriptionLimiter(1)})
+	conn, err := dialInProcess(gs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		gs.Stop()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewRedditClient(conn).MonitorUpdates(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&pb.MonitorUpdatesRequest{ContentType: pb.ContentType_POST, ContentID: 1}))
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pb.ContentType_POST, update.GetContentType())
+
+	require.NoError(t, stream.Send(&pb.MonitorUpdatesRequest{ContentType: pb.ContentType_POST, ContentID: 1, Unsubscribe: true}))
+	require.NoError(t, stream.Send(&pb.MonitorUpdatesRequest{ContentType: pb.ContentType_COMMENT, ContentID: 2}))
+	update, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, pb.ContentType_COMMENT, update.GetContentType())
+	assert.Equal(t, int32(2), update.GetContentID())
+}
+
+func TestRemoveID(t *testing.T) {
+	ids, removed := removeID([]int{1, 2, 1}, 1)
+	assert.True(t, removed)
+	assert.Equal(t, []int{2, 1}, ids)
+
+	ids, removed = removeID(ids, 3)
+	assert.False(t, removed)
+	assert.Equal(t, []int{2, 1}, ids)
+}
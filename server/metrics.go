@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+	metrics         = promauto.With(metricsRegistry)
+
+	rpcHandled = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "reddit_grpc_server_handling_seconds",
+		Help:    "Latency of RPCs handled by the server, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	storageQueryDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "reddit_storage_query_duration_seconds",
+		Help:    "Latency of storage queries, by storage method.",
+		Buckets: []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"method"})
+	monitorStreams = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "reddit_monitor_streams_active",
+		Help: "Number of open MonitorUpdates streams.",
+	})
+	monitorSubscriptions = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "reddit_monitor_subscriptions_active",
+		Help: "Number of posts and comments monitored across all open MonitorUpdates streams.",
+	})
+	votes = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_votes_total",
+		Help: "Total number of votes cast, by content type and direction. Use rate() for votes per second.",
+	}, []string{"content_type", "direction"})
+	cacheRequests = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "reddit_cache_requests_total",
+		Help: "Total number of storage reads looked up in the cache, by storage method and result: hit or miss.",
+	}, []string{"method", "result"})
+	cacheEntries = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "reddit_cache_entries",
+		Help: "Number of storage reads held in the cache.",
+	})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Record the duration of a storage query when the returned function is called
+func observeQuery(method string) func() {
+	start := time.Now()
+	return func() {
+		storageQueryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Count a vote on a post or comment
+func observeVote(contentType string, upvote bool) {
+	direction := "down"
+	if upvote {
+		direction = "up"
+	}
+	votes.WithLabelValues(contentType, direction).Inc()
+}
+
+// Count a read served from the cache, or missing it
+func observeCache(method string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.WithLabelValues(method, result).Inc()
+}
+
+func observeRPC(fullMethod string, start time.Time, err error) {
+	method := strings.TrimPrefix(fullMethod, "/")
+	rpcHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// Record the count, status code and latency of every unary call
+func unaryMetricsInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+// Record the count, status code and lifetime of every streaming call
+func streamMetricsInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRPC(info.FullMethod, start, err)
+	return err
+}
+
+// Serve the Prometheus metrics on addr at /metrics
+func serveMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+	return server.ListenAndServe()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryMetricsInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/reddit.Reddit/GetComment"}
+	handled := rpcHandled.WithLabelValues("reddit.Reddit/GetComment", "NotFound")
+	before := testutil.ToFloat64(handled)
+
+	unaryMetricsInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+
+	assert.Equal(t, before+1, testutil.ToFloat64(handled))
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(rpcDuration, "reddit_grpc_server_handling_seconds"), 1)
+}
+
+func TestObserveVote(t *testing.T) {
+	upvotes := votes.WithLabelValues("post", "up")
+	before := testutil.ToFloat64(upvotes)
+
+	observeVote("post", true)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(upvotes))
+}
@@ -0,0 +1,39 @@
+-- The tables of the SQLite database, with column types of PostgreSQL
+CREATE TABLE "user" (
+  id serial PRIMARY KEY
+);
+
+CREATE TABLE subreddit (
+  id serial PRIMARY KEY,
+  name text NOT NULL,
+  state integer NOT NULL,
+  tags text NOT NULL DEFAULT ''
+);
+
+CREATE TABLE post (
+  id serial PRIMARY KEY,
+  title text NOT NULL,
+  content text NOT NULL,
+  subRedditID integer NOT NULL,
+  videoURL text,
+  imageURL text,
+  authorID integer,
+  score integer NOT NULL DEFAULT 0,
+  state integer NOT NULL,
+  publicationDate date
+);
+
+CREATE TABLE comment (
+  id serial PRIMARY KEY,
+  content text NOT NULL,
+  authorID integer,
+  score integer NOT NULL DEFAULT 0,
+  state integer NOT NULL,
+  publicationDate date,
+  parent integer NOT NULL,
+  parentID integer NOT NULL
+);
+
+-- Pages of the posts of a subreddit, and the top replies of a post or comment
+CREATE INDEX post_subreddit ON post (subRedditID, id);
+CREATE INDEX comment_parent ON comment (parent, parentID, score DESC, id);
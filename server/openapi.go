@@ -0,0 +1,186 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Generate the OpenAPI 3 document of the gateway from its routes and the
+// descriptors of the messages they carry
+func (g *gateway) openAPI() map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]any{}
+
+	for _, rt := range routes {
+		method := g.service.Methods().ByName(protoreflect.Name(rt.rpc))
+		input, output := method.Input(), method.Output()
+		addSchema(schemas, output)
+
+		operation := map[string]any{
+			"operationId": rt.rpc,
+			"summary":     rt.summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "A successful response.",
+					"content":     responseContent(rt, output),
+				},
+				"default": map[string]any{
+					"description": "An error response.",
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": schemaRef("google.rpc.Status")},
+					},
+				},
+			},
+		}
+
+		// Parameters from the path, and from the query for fields left out of the body
+		parameters := []any{}
+		pathParams := map[string]bool{}
+		for _, match := range pathParamPattern.FindAllStringSubmatch(rt.pattern, -1) {
+			pathParams[match[1]] = true
+			parameters = append(parameters, map[string]any{
+				"name": match[1], "in": "path", "required": true,
+				"schema": fieldSchema(input.Fields().ByJSONName(match[1])),
+			})
+		}
+		if rt.rpc == "WatchUpdates" {
+			for _, name := range []string{"post", "comment"} {
+				parameters = append(parameters, map[string]any{
+					"name": name, "in": "query", "description": "ID of a " + name + " to watch, may be repeated",
+					"schema": map[string]any{"type": "array", "items": map[string]any{"type": "integer", "format": "int32"}},
+				})
+			}
+		} else if rt.body != "*" {
+			queryNames := map[string]string{}
+			for alias, name := range rt.aliases {
+				queryNames[name] = alias
+			}
+			fields := input.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				field := fields.Get(i)
+				if pathParams[field.JSONName()] || string(field.Name()) == rt.body || field.Kind() == protoreflect.MessageKind {
+					continue
+				}
+				name := field.JSONName()
+				if alias, ok := queryNames[name]; ok {
+					name = alias
+				}
+				parameters = append(parameters, map[string]any{"name": name, "in": "query", "schema": fieldSchema(field)})
+			}
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if rt.body != "" {
+			body := input
+			if rt.body != "*" {
+				body = input.Fields().ByName(protoreflect.Name(rt.body)).Message()
+			}
+			addSchema(schemas, body)
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaRef(string(body.FullName()))},
+				},
+			}
+		}
+
+		item, ok := paths[rt.pattern].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[rt.pattern] = item
+		}
+		item[strings.ToLower(rt.method)] = operation
+	}
+
+	schemas["google.rpc.Status"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "integer", "format": "int32"},
+			"message": map[string]any{"type": "string"},
+			"details": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+		},
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Reddit API",
+			"version": "v1",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func responseContent(rt route, output protoreflect.MessageDescriptor) map[string]any {
+	if rt.rpc == "WatchUpdates" {
+		return map[string]any{
+			"application/x-ndjson": map[string]any{"schema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"result": schemaRef(string(output.FullName()))},
+			}},
+			"text/event-stream": map[string]any{"schema": map[string]any{
+				"type":        "string",
+				"description": "update events carrying a " + string(output.FullName()) + ", and an error event if the stream fails",
+			}},
+		}
+	}
+	return map[string]any{
+		"application/json": map[string]any{"schema": schemaRef(string(output.FullName()))},
+	}
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// Add the schema of a message and of every message it references
+func addSchema(schemas map[string]any, msg protoreflect.MessageDescriptor) {
+	name := string(msg.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+	properties := map[string]any{}
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[field.JSONName()] = fieldSchema(field)
+		if field.Kind() == protoreflect.MessageKind {
+			addSchema(schemas, field.Message())
+		}
+	}
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor) map[string]any {
+	var schema map[string]any
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.BoolKind:
+		schema = map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		schema = map[string]any{"type": "string"}
+	case protoreflect.EnumKind:
+		values := []string{}
+		for i := 0; i < field.Enum().Values().Len(); i++ {
+			values = append(values, string(field.Enum().Values().Get(i).Name()))
+		}
+		schema = map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageKind:
+		schema = schemaRef(string(field.Message().FullName()))
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	if field.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
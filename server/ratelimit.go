@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	retryAfterKey = "retry-after"
+
+	// Limits applying to every RPC without its own entry
+	defaultLimitKey = "*"
+
+	// Buckets untouched for this long are full again and can be dropped
+	bucketIdleTTL = 10 * time.Minute
+)
+
+// Token bucket settings: requests per second and burst size
+type limit struct {
+	rate  rate.Limit
+	burst int
+}
+
+// Parse a comma separated list of Method=rate:burst entries, where Method
+// is the RPC name without its service or * for every other RPC
+func parseRateLimits(spec string) (map[string]limit, error) {
+	limits := map[string]limit{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		r, b, ok2 := strings.Cut(value, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid rate limit %q, want Method=rate:burst", entry)
+		}
+		perSecond, err := strconv.ParseFloat(r, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate in %q: %v", entry, err)
+		}
+		burst, err := strconv.Atoi(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid burst in %q: %v", entry, err)
+		}
+		limits[method] = limit{rate: rate.Limit(perSecond), burst: burst}
+	}
+	return limits, nil
+}
+
+// Identify the caller, by the subject of its verified client certificate
+// when it authenticated with mutual TLS, or else by its IP address. Calls
+// from the in-process HTTP gateway carry the identity of the HTTP caller.
+func callerID(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	if p.Addr.Network() == inProcessNetwork {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(forwardedUserKey); len(values) > 0 {
+			return "user:" + values[0]
+		}
+		if values := md.Get(forwardedForKey); len(values) > 0 {
+			return "ip:" + values[0]
+		}
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+		return "user:" + info.State.VerifiedChains[0][0].Subject.CommonName
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "ip:" + host
+}
+
+// Error returned to callers over their limit, telling them when to retry
+func resourceExhausted(retryAfter time.Duration, format string, args ...any) error {
+	st := status.Newf(codes.ResourceExhausted, format, args...)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// Metadata carrying the retry delay in whole seconds, rounded up
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	return metadata.Pairs(retryAfterKey, strconv.Itoa(seconds))
+}
+
+type bucketKey struct {
+	method string
+	caller string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Token bucket rate limiter keyed by RPC and caller
+type rateLimiter struct {
+	limits map[string]limit
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastPrune time.Time
+}
+
+func newRateLimiter(limits map[string]limit) *rateLimiter {
+	return &rateLimiter{
+		limits:    limits,
+		buckets:   map[bucketKey]*bucket{},
+		lastPrune: time.Now(),
+	}
+}
+
+// Take a token for the call, or report how long the caller has to wait
+func (l *rateLimiter) allow(fullMethod string, caller string, now time.Time) (bool, time.Duration) {
+	method := path.Base(fullMethod)
+	lim, ok := l.limits[method]
+	if !ok {
+		if lim, ok = l.limits[defaultLimitKey]; !ok {
+			return true, 0
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	key := bucketKey{method: method, caller: caller}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(lim.rate, lim.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Drop idle buckets, at most once per idle period
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < bucketIdleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// Reject unary calls over the caller's limit for the RPC
+func (l *rateLimiter) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if ok, retryAfter := l.allow(info.FullMethod, callerID(ctx), time.Now()); !ok {
+		grpc.SetHeader(ctx, retryAfterMetadata(retryAfter))
+		return nil, resourceExhausted(retryAfter, "rate limit exceeded for %s, retry after %v", path.Base(info.FullMethod), retryAfter)
+	}
+	return handler(ctx, req)
+}
+
+// Reject new streams over the caller's limit for the RPC
+func (l *rateLimiter) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if ok, retryAfter := l.allow(info.FullMethod, callerID(ss.Context()), time.Now()); !ok {
+		ss.SetHeader(retryAfterMetadata(retryAfter))
+		return resourceExhausted(retryAfter, "rate limit exceeded for %s, retry after %v", path.Base(info.FullMethod), retryAfter)
+	}
+	return handler(srv, ss)
+}
+
+// Cap on the number of contents a caller monitors across its open streams
+type subscriptionLimiter struct {
+	max int
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newSubscriptionLimiter(max int) *subscriptionLimiter {
+	return &subscriptionLimiter{max: max, counts: map[string]int{}}
+}
+
+// Count a new subscription for the caller, unless it is at its cap.
+// A nil limiter or a cap of zero allows any number of subscriptions.
+func (l *subscriptionLimiter) acquire(caller string) bool {
+	if l == nil || l.max <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.counts[caller] >= l.max {
+		return false
+	}
+	l.counts[caller]++
+	return true
+}
+
+// Give back n subscriptions of the caller
+func (l *subscriptionLimiter) release(caller string, n int) {
+	if l == nil || l.max <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.counts[caller] -= n
+	if l.counts[caller] <= 0 {
+		delete(l.counts, caller)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := parseRateLimits("VotePost=5:10, *=0.5:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]limit{
+		"VotePost": {rate: 5, burst: 10},
+		"*":        {rate: 0.5, burst: 1},
+	}, limits)
+
+	_, err = parseRateLimits("VotePost=5")
+	assert.Error(t, err)
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := newRateLimiter(map[string]limit{"VotePost": {rate: 1, burst: 2}})
+	now := time.Now()
+
+	// The burst is allowed, then the caller has to wait for a new token
+	for i := 0; i < 2; i++ {
+		ok, _ := limiter.allow("/reddit.Reddit/VotePost", "ip:10.0.0.1", now)
+		assert.True(t, ok)
+	}
+	ok, retryAfter := limiter.allow("/reddit.Reddit/VotePost", "ip:10.0.0.1", now)
+	assert.False(t, ok)
+	assert.InDelta(t, time.Second, retryAfter, float64(10*time.Millisecond))
+
+	// Other callers and other methods have their own buckets
+	ok, _ = limiter.allow("/reddit.Reddit/VotePost", "ip:10.0.0.2", now)
+	assert.True(t, ok)
+	ok, _ = limiter.allow("/reddit.Reddit/GetPost", "ip:10.0.0.1", now)
+	assert.True(t, ok)
+
+	ok, _ = limiter.allow("/reddit.Reddit/VotePost", "ip:10.0.0.1", now.Add(time.Second))
+	assert.True(t, ok)
+}
+
+func TestRateLimiterUnaryInterceptor(t *testing.T) {
+	limiter := newRateLimiter(map[string]limit{"*": {rate: rate.Every(time.Minute), burst: 1}})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/reddit.Reddit/CreateComment"}
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	_, err := limiter.unaryInterceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+
+	_, err = limiter.unaryInterceptor(ctx, nil, info, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo := st.Details()[0].(*errdetails.RetryInfo)
+	assert.InDelta(t, time.Minute, retryInfo.RetryDelay.AsDuration(), float64(time.Second))
+}
+
+func TestCallerID(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	assert.Equal(t, "ip:10.0.0.1", callerID(ctx))
+
+	// Callers authenticated with a client certificate are identified by its subject
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}}
+	info := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: info})
+	assert.Equal(t, "user:alice", callerID(ctx))
+}
+
+func TestSubscriptionLimiter(t *testing.T) {
+	limiter := newSubscriptionLimiter(2)
+	assert.True(t, limiter.acquire("ip:10.0.0.1"))
+	assert.True(t, limiter.acquire("ip:10.0.0.1"))
+	assert.False(t, limiter.acquire("ip:10.0.0.1"))
+	assert.True(t, limiter.acquire("ip:10.0.0.2"))
+
+	limiter.release("ip:10.0.0.1", 1)
+	assert.True(t, limiter.acquire("ip:10.0.0.1"))
+
+	var unlimited *subscriptionLimiter
+	assert.True(t, unlimited.acquire("ip:10.0.0.1"))
+}
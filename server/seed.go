@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// Sizes and shape of a synthetic dataset
+type seedConfig struct {
+	seed       int64
+	users      int
+	subReddits int
+	posts      int
+	comments   int
+	maxDepth   int       // Of comment trees, counting top level comments as 1
+	start      time.Time // Of the first post
+	days       int       // Over which posts are published
+}
+
+// Words of generated names and texts, in the style of the example database
+var seedWords = strings.Fields(`
+	lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod
+	tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam
+	quis nostrud exercitation ullamco laboris nisi aliquip ex ea commodo
+	consequat duis aute irure in reprehenderit voluptate velit esse cillum
+	fugiat nulla pariatur excepteur sint occaecat cupidatat non proident sunt
+	culpa qui officia deserunt mollit anim id est laborum interdum efficitur
+	massa sodales penatibus felis finibus senectus ullamcorper hendrerit netus
+	urna pharetra lectus justo vel fermentum aliquet litora pulvinar nascetur
+	euismod suscipit gravida`)
+
+// Generate the records of a synthetic dataset, which depend only on the
+// config. Posts pick subreddits and authors by Zipf popularity, scores
+// follow a log-normal distribution, and comments reply to the recent
+// comments of their post, more so on popular posts.
+func generateSeed(config seedConfig) []*pb.ExportRecord {
+	r := rand.New(rand.NewSource(config.seed))
+	records := make([]*pb.ExportRecord, 0, config.users+config.subReddits+config.posts+config.comments)
+
+	for id := 1; id <= config.users; id++ {
+		records = append(records, &pb.ExportRecord{Record: &pb.ExportRecord_User{User: &pb.User{Id: int32(id)}}})
+	}
+
+	for id := 1; id <= config.subReddits; id++ {
+		state := pb.SubRedditState_PUBLIC
+		switch p := r.Float64(); {
+		case p < 0.05:
+			state = pb.SubRedditState_HIDDEN
+		case p < 0.15:
+			state = pb.SubRedditState_PRIVATE
+		}
+		tags := make([]string, 1+r.Intn(3))
+		for i := range tags {
+			tags[i] = seedWords[r.Intn(len(seedWords))]
+		}
+		subReddit := &pb.SubReddit{Id: int32(id), Name: fmt.Sprintf("r/%s_%d", seedWords[r.Intn(len(seedWords))], id), State: state, Tags: tags}
+		records = append(records, &pb.ExportRecord{Record: &pb.ExportRecord_SubReddit{SubReddit: subReddit}})
+	}
+
+	// A few subreddits and users make most of the content
+	popularSubReddit := rand.NewZipf(r, 1.2, 1, uint64(config.subReddits-1))
+	popularUser := rand.NewZipf(r, 1.1, 1, uint64(config.users-1))
+	author := func() *pb.User {
+		if r.Float64() < 0.05 {
+			// Deleted account
+			return nil
+		}
+		return &pb.User{Id: int32(popularUser.Uint64()) + 1}
+	}
+
+	postDays := make([]int, config.posts)
+	weights := make([]float64, config.posts)
+	for i := range postDays {
+		postDays[i] = r.Intn(config.days)
+	}
+	sort.Ints(postDays)
+	for i := 0; i < config.posts; i++ {
+		post := &pb.Post{
+			Id:              int32(i + 1),
+			Title:           sentence(r, 2, 8),
+			Content:         sentence(r, 5, 40),
+			SubReddit:       &pb.SubReddit{Id: int32(popularSubReddit.Uint64()) + 1},
+			Author:          author(),
+			Score:           logNormalScore(r, 1.5, 1.6),
+			State:           pb.PostState_NORMAL_POST,
+			PublicationDate: seedDate(config.start, postDays[i]),
+		}
+		switch p := r.Float64(); {
+		case p < 0.2:
+			url := fmt.Sprintf("https://example.com/images/%d.jpg", post.Id)
+			post.ImageURL = &url
+		case p < 0.3:
+			url := fmt.Sprintf("https://example.com/videos/%d.mp4", post.Id)
+			post.VideoURL = &url
+		}
+		switch p := r.Float64(); {
+		case p < 0.02:
+			post.State = pb.PostState_HIDDEN_POST
+		case p < 0.05:
+			post.State = pb.PostState_LOCKED_POST
+		}
+		weights[i] = math.Log(float64(max(post.Score, 0)) + 2)
+		records = append(records, &pb.ExportRecord{Record: &pb.ExportRecord_Post{Post: post}})
+	}
+	if config.posts == 0 {
+		return records
+	}
+
+	// Comments go to posts by their score
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+	type treeComment struct {
+		id, depth int32
+		day       int
+	}
+	trees := make([][]treeComment, config.posts)
+	for id := int32(1); id <= int32(config.comments); id++ {
+		postIndex := sort.SearchFloat64s(cumulative, r.Float64()*total)
+		tree := trees[postIndex]
+		comment := &pb.Comment{
+			Id:      id,
+			Content: sentence(r, 3, 25),
+			Author:  author(),
+			State:   pb.CommentState_NORMAL_COMMENT,
+			Parent:  pb.ContentType_POST,
+			// The post index is its ID less 1
+			ParentID: int32(postIndex + 1),
+		}
+		depth, day := int32(1), postDays[postIndex]
+		if len(tree) > 0 && r.Float64() < 0.7 {
+			// Replies mostly go to the latest comments of the thread
+			parent := tree[len(tree)-1-min(int(r.ExpFloat64()*3), len(tree)-1)]
+			if parent.depth < int32(config.maxDepth) {
+				comment.Parent, comment.ParentID = pb.ContentType_COMMENT, parent.id
+				depth, day = parent.depth+1, parent.day
+			}
+		}
+		day = min(day+int(r.ExpFloat64()), config.days-1)
+		comment.PublicationDate = seedDate(config.start, day)
+		// Replies deep in a thread are seen and voted on less
+		comment.Score = logNormalScore(r, 1.2-0.3*float64(depth), 1.2)
+		if r.Float64() < 0.03 {
+			comment.State = pb.CommentState_LOCKED_COMMENT
+		}
+		trees[postIndex] = append(tree, treeComment{id: id, depth: depth, day: day})
+		records = append(records, &pb.ExportRecord{Record: &pb.ExportRecord_Comment{Comment: comment}})
+	}
+	return records
+}
+
+// Score of content, whose upvotes less 1 are log-normally distributed, and
+// which is downvoted below 0 now and then
+func logNormalScore(r *rand.Rand, mu, sigma float64) int32 {
+	if r.Float64() < 0.08 {
+		return -int32(r.Intn(5))
+	}
+	return int32(math.Exp(mu+sigma*r.NormFloat64())) - 1
+}
+
+// Capitalized sentence of between lo and hi words
+func sentence(r *rand.Rand, lo, hi int) string {
+	words := make([]string, lo+r.Intn(hi-lo+1))
+	for i := range words {
+		words[i] = seedWords[r.Intn(len(seedWords))]
+	}
+	text := strings.Join(words, " ")
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func seedDate(start time.Time, day int) *date.Date {
+	t := start.AddDate(0, 0, day)
+	return &date.Date{Year: int32(t.Year()), Month: int32(t.Month()), Day: int32(t.Day())}
+}
+
+// Records of a slice, as read from an export
+type sliceRecordReader struct {
+	records []*pb.ExportRecord
+}
+
+func (r *sliceRecordReader) Read() (*pb.ExportRecord, error) {
+	if len(r.records) == 0 {
+		return nil, io.EOF
+	}
+	record := r.records[0]
+	r.records = r.records[1:]
+	return record, nil
+}
+
+// Fill an empty database with a synthetic dataset, or write it as an
+// export to restore elsewhere
+func runSeed(ctx context.Context, s Storage, args []string) (err error) {
+	fs := commandFlags("seed")
+	config := seedConfig{}
+	fs.Int64Var(&config.seed, "seed", 1, "The seed of the dataset, which is the same for the same seed and sizes")
+	fs.IntVar(&config.users, "users", 100, "The number of users")
+	fs.IntVar(&config.subReddits, "subreddits", 10, "The number of subreddits")
+	fs.IntVar(&config.posts, "posts", 1000, "The number of posts")
+	fs.IntVar(&config.comments, "comments", 10000, "The number of comments")
+	fs.IntVar(&config.maxDepth, "depth", 10, "The maximum depth of comment trees")
+	start := fs.String("start", "2023-01-01", "The date of the first post")
+	fs.IntVar(&config.days, "days", 365, "The number of days posts are published over")
+	output := fs.String("o", "", "Write the dataset as an export to this file, - for stdout, instead of to the database")
+	format := fs.String("format", formatNDJSON, "The format of the export: ndjson or binary")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	if config.users < 1 || config.subReddits < 1 || config.posts < 0 || config.comments < 0 || config.maxDepth < 1 || config.days < 1 {
+		return errUsage
+	}
+	if config.start, err = time.Parse(time.DateOnly, *start); err != nil {
+		return fmt.Errorf("invalid start date: %w", err)
+	}
+	records := generateSeed(config)
+
+	if *output == "" {
+		restored, err := s.Restore(ctx, &sliceRecordReader{records})
+		if err != nil {
+			return err
+		}
+		slog.Info("Seeded", slog.Int("records", restored))
+		return nil
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, f.Close()) }()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+	w, err := newRecordWriter(bw, *format)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSeedConfig(seed int64) seedConfig {
+	return seedConfig{
+		seed: seed, users: 50, subReddits: 5, posts: 200, comments: 3000, maxDepth: 8,
+		start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), days: 30,
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	records := generateSeed(testSeedConfig(1))
+	require.Len(t, records, 50+5+200+3000)
+
+	same := generateSeed(testSeedConfig(1))
+	for i := range records {
+		require.True(t, proto.Equal(records[i], same[i]), "record %d differs", i)
+	}
+	other := generateSeed(testSeedConfig(2))
+	assert.False(t, proto.Equal(records[len(records)-1], other[len(other)-1]))
+
+	depths := map[int32]int32{}
+	maxDepth, negative := int32(0), 0
+	for _, record := range records[50+5:] {
+		switch r := record.GetRecord().(type) {
+		case *pb.ExportRecord_Post:
+			day := r.Post.GetPublicationDate()
+			assert.Equal(t, int32(2023), day.GetYear())
+			assert.Equal(t, int32(1), day.GetMonth())
+		case *pb.ExportRecord_Comment:
+			c := r.Comment
+			depth := int32(1)
+			if c.GetParent() == pb.ContentType_COMMENT {
+				parentDepth, ok := depths[c.GetParentID()]
+				require.True(t, ok, "replies follow their parent")
+				depth = parentDepth + 1
+			}
+			depths[c.GetId()] = depth
+			maxDepth = max(maxDepth, depth)
+			if c.GetScore() < 0 {
+				negative++
+			}
+		}
+	}
+	assert.Equal(t, int32(8), maxDepth)
+	assert.Greater(t, negative, 0)
+}
+
+func TestSeed(t *testing.T) {
+	client := newEmptySQLClient(t)
+	ctx := context.Background()
+	args := []string{"seed", "-seed", "7", "-users", "20", "-subreddits", "3", "-posts", "30", "-comments", "300", "-days", "10"}
+	require.NoError(t, runCommand(ctx, client, args))
+
+	subReddits, err := client.ListSubReddits(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, subReddits)
+	top, err := client.GetTopComments(ctx, 1, 100)
+	require.NoError(t, err)
+	for i := 1; i < len(top); i++ {
+		assert.GreaterOrEqual(t, top[i-1].GetScore(), top[i].GetScore())
+	}
+
+	// The export of the seeded database is the dataset written by seed
+	file := filepath.Join(t.TempDir(), "seed.ndjson")
+	require.NoError(t, runCommand(ctx, client, append(args, "-o", file)))
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, exportNDJSON(t, client), string(data))
+
+	assert.ErrorContains(t, runCommand(ctx, client, args), "not empty")
+	assert.ErrorIs(t, runCommand(ctx, client, []string{"seed", "-users", "0"}), errUsage)
+}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/genproto/googleapis/type/date"
 
 	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
 )
@@ -16,21 +21,48 @@ type SQLClient struct {
 	db *sql.DB
 }
 
-func NewSQLClient() (*SQLClient, error) {
-	db, err := sql.Open("sqlite3", db_file)
+func NewSQLClient(file string) (*SQLClient, error) {
+	db, err := sql.Open("sqlite3", file)
 	if err != nil {
 		return nil, err
 	}
 	return &SQLClient{db: db}, nil
 }
 
-func (c *SQLClient) CreatePost(post *pb.Post) (int, error) {
+// Tables of the database, as created in the example database
+const schema = `
+CREATE TABLE IF NOT EXISTS "user" ("id" integer, PRIMARY KEY (id));
+CREATE TABLE IF NOT EXISTS "subreddit" ("id" integer,"name" text,"state" integer,"tags" text, PRIMARY KEY (id));
+CREATE TABLE IF NOT EXISTS "post" ("id" integer,"title" text,"content" text,"subRedditID" integer,"videoURL" text,"imageURL" text,"authorID" integer,"score" integer,"state" integer, "publicationDate" datetime, PRIMARY KEY (id));
+CREATE TABLE IF NOT EXISTS "comment" ("id" integer,"content" text,"authorID" integer,"score" integer,"state" integer,"publicationDate" datetime, "parent" integer, "parentID" integer, PRIMARY KEY (id));
+`
+
+// Create the tables missing from the database, so that an empty file can
+// be filled
+func (c *SQLClient) CreateSchema(ctx context.Context) error {
+	_, err := c.db.ExecContext(ctx, schema)
+	return err
+}
+
+func (c *SQLClient) Close() error {
+	return c.db.Close()
+}
+
+// Check that the database can still be reached
+func (c *SQLClient) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+func (c *SQLClient) CreatePost(ctx context.Context, post *pb.Post) (_ int, err error) {
+	ctx, end := startQuery(ctx, "CreatePost")
+	defer func() { end(err) }()
+
 	// Insert the post into the database
 	res, err :=
-		c.db.Exec("INSERT INTO post (title, content, subRedditID, videoURL, imageURL, authorID, score, state, publicationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		c.db.ExecContext(ctx, "INSERT INTO post (title, content, subRedditID, videoURL, imageURL, authorID, score, state, publicationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
 			post.GetTitle(), post.GetContent(), post.GetSubReddit().GetId(),
 			post.GetVideoURL(), post.GetImageURL(), post.GetAuthor().GetId(),
-			post.GetScore(), post.GetState().Number(), post.GetPublicationDate(),
+			post.GetScore(), post.GetState().Number(), dateValue(post.GetPublicationDate()),
 		)
 	if err != nil {
 		return -1, err
@@ -42,14 +74,17 @@ func (c *SQLClient) CreatePost(post *pb.Post) (int, error) {
 	return int(id), nil
 }
 
-func (c *SQLClient) VotePost(id int, upvote bool) (int, error) {
+func (c *SQLClient) VotePost(ctx context.Context, id int, upvote bool) (_ int, err error) {
+	ctx, end := startQuery(ctx, "VotePost")
+	defer func() { end(err) }()
+
 	// Increment/Decrement the score of the post
-	_, err := c.db.Exec("UPDATE post SET score = score + (?) WHERE id = (?)", upvote, id)
+	_, err = c.db.ExecContext(ctx, "UPDATE post SET score = score + (?) WHERE id = (?)", voteDelta(upvote), id)
 	if err != nil {
 		return -1, err
 	}
 	// Get the new score
-	row := c.db.QueryRow("SELECT score FROM post WHERE id = (?)", id)
+	row := c.db.QueryRowContext(ctx, "SELECT score FROM post WHERE id = (?)", id)
 	var newScore int
 	if err := row.Scan(&newScore); err != nil {
 		return -1, err
@@ -57,29 +92,57 @@ func (c *SQLClient) VotePost(id int, upvote bool) (int, error) {
 	return newScore, nil
 }
 
-func (c *SQLClient) GetPost(id int) (*pb.Post, error) {
+// Score change of a vote
+func voteDelta(upvote bool) int {
+	if upvote {
+		return 1
+	}
+	return -1
+}
+
+func (c *SQLClient) GetPost(ctx context.Context, id int) (_ *pb.Post, err error) {
+	ctx, end := startQuery(ctx, "GetPost")
+	defer func() { end(err) }()
+
 	// Get the post from the database
-	row := c.db.QueryRow("SELECT * from post WHERE id = (?)", id)
-	post := &pb.Post{
-		SubReddit: &pb.SubReddit{},
-		Author:    &pb.User{},
+	row := c.db.QueryRowContext(ctx, "SELECT "+postColumns+" from post WHERE id = (?)", id)
+	return scanPost(row)
+}
+
+// Get the posts of the IDs that exist, in no particular order
+func (c *SQLClient) GetPosts(ctx context.Context, ids []int) (_ []*pb.Post, err error) {
+	ctx, end := startQuery(ctx, "GetPosts")
+	defer func() { end(err) }()
+
+	if len(ids) == 0 {
+		return []*pb.Post{}, nil
 	}
-	if err := row.Scan(
-		&post.Id, &post.Title, &post.Content, &post.SubReddit.Id,
-		&post.VideoURL, &post.ImageURL, &post.Author.Id, &post.Score,
-		&post.State, &post.PublicationDate,
-	); err == sql.ErrNoRows {
+	list, args := idList(ids)
+	rows, err := c.db.QueryContext(ctx, "SELECT "+postColumns+" FROM post WHERE id IN ("+list+")", args...)
+	if err != nil {
 		return nil, err
 	}
-	return post, nil
+	return scanPosts(rows)
+}
+
+// Placeholders and arguments of the IDs of an IN list
+func idList(ids []int) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", "), args
 }
 
-func (c *SQLClient) CreateComment(comment *pb.Comment) (int, error) {
+func (c *SQLClient) CreateComment(ctx context.Context, comment *pb.Comment) (_ int, err error) {
+	ctx, end := startQuery(ctx, "CreateComment")
+	defer func() { end(err) }()
+
 	// Insert the comment into the database
 	res, err :=
-		c.db.Exec("INSERT INTO comment (content, authorID, score, state, publicationDate, parent, parentID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		c.db.ExecContext(ctx, "INSERT INTO comment (content, authorID, score, state, publicationDate, parent, parentID) VALUES (?, ?, ?, ?, ?, ?, ?)",
 			comment.GetContent(), comment.GetAuthor().GetId(),
-			comment.GetScore(), comment.GetState().Number(), comment.GetPublicationDate(),
+			comment.GetScore(), comment.GetState().Number(), dateValue(comment.GetPublicationDate()),
 			comment.GetParent().Number(), comment.GetParentID(),
 		)
 	if err != nil {
@@ -92,14 +155,17 @@ func (c *SQLClient) CreateComment(comment *pb.Comment) (int, error) {
 	return int(id), nil
 }
 
-func (c *SQLClient) VoteComment(id int, upvote bool) (int, error) {
+func (c *SQLClient) VoteComment(ctx context.Context, id int, upvote bool) (_ int, err error) {
+	ctx, end := startQuery(ctx, "VoteComment")
+	defer func() { end(err) }()
+
 	// Increment/Decrement the score of the comment
-	_, err := c.db.Exec("UPDATE comment SET score = score + (?) WHERE id = (?)", upvote, id)
+	_, err = c.db.ExecContext(ctx, "UPDATE comment SET score = score + (?) WHERE id = (?)", voteDelta(upvote), id)
 	if err != nil {
 		return -1, err
 	}
 	// Get the new score
-	row := c.db.QueryRow("SELECT score FROM comment WHERE id = (?)", id)
+	row := c.db.QueryRowContext(ctx, "SELECT score FROM comment WHERE id = (?)", id)
 	var newScore int
 	if err := row.Scan(&newScore); err != nil {
 		return -1, err
@@ -107,94 +173,273 @@ func (c *SQLClient) VoteComment(id int, upvote bool) (int, error) {
 	return newScore, nil
 }
 
-func (c *SQLClient) GetComment(id int) (*pb.Comment, error) {
+func (c *SQLClient) GetComment(ctx context.Context, id int) (_ *pb.Comment, err error) {
+	ctx, end := startQuery(ctx, "GetComment")
+	defer func() { end(err) }()
+
 	// Get the comment from the database
-	row := c.db.QueryRow("SELECT * from comment WHERE id = (?)", id)
-	comment := &pb.Comment{
-		Author: &pb.User{},
+	row := c.db.QueryRowContext(ctx, "SELECT "+commentColumns+" from comment WHERE id = (?)", id)
+	return scanComment(row)
+}
+
+// Get the comments of the IDs that exist, in no particular order
+func (c *SQLClient) GetComments(ctx context.Context, ids []int) (_ []*pb.Comment, err error) {
+	ctx, end := startQuery(ctx, "GetComments")
+	defer func() { end(err) }()
+
+	if len(ids) == 0 {
+		return []*pb.Comment{}, nil
 	}
-	if err := row.Scan(
-		&comment.Id, &comment.Content, &comment.Author.Id, &comment.Score,
-		&comment.State, &comment.PublicationDate, &comment.Parent, &comment.ParentID,
-	); err == sql.ErrNoRows {
+	list, args := idList(ids)
+	rows, err := c.db.QueryContext(ctx, "SELECT "+commentColumns+" FROM comment WHERE id IN ("+list+")", args...)
+	if err != nil {
 		return nil, err
 	}
-	return comment, nil
+	return scanComments(rows)
 }
 
-func (c *SQLClient) GetTopComments(postID int, quantity int) ([]*pb.Comment, error) {
+func (c *SQLClient) GetTopComments(ctx context.Context, postID int, quantity int) (_ []*pb.Comment, err error) {
+	ctx, end := startQuery(ctx, "GetTopComments")
+	defer func() { end(err) }()
+
 	// Get the comment from the database
-	rows, err := c.db.Query(
-		"SELECT * from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC LIMIT (?)",
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT "+commentColumns+" from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC, id LIMIT (?)",
 		pb.ContentType_POST, postID, quantity)
 	if err != nil {
 		return nil, err
 	}
-	comments := []*pb.Comment{}
+	comments, err := scanComments(rows)
+	if err != nil {
+		return nil, err
+	}
 
-	for rows.Next() {
-		comment := &pb.Comment{
-			Author: &pb.User{},
+	return comments, nil
+}
+
+func (c *SQLClient) ExpandCommentBranch(ctx context.Context, id int, quantity int) (_ []*pb.Comment, err error) {
+	ctx, end := startQuery(ctx, "ExpandCommentBranch")
+	defer func() { end(err) }()
+
+	// Get the comment from the database
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT "+commentColumns+" from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC, id LIMIT (?)",
+		pb.ContentType_COMMENT, id, quantity)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := scanComments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		// Get the replies of the comment
+		rows, err := c.db.QueryContext(ctx,
+			"SELECT "+commentColumns+" from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC, id LIMIT (?)",
+			pb.ContentType_COMMENT, comment.Id, quantity)
+		if err != nil {
+			return nil, err
 		}
-		if err := rows.Scan(
-			&comment.Id, &comment.Content, &comment.Author.Id, &comment.Score,
-			&comment.State, &comment.PublicationDate, &comment.Parent, &comment.ParentID,
-		); err != nil {
+		replies, err := scanComments(rows)
+		if err != nil {
 			return nil, err
 		}
-		comments = append(comments, comment)
+		comment.Children = replies
 	}
 
 	return comments, nil
 }
 
-func (c *SQLClient) ExpandCommentBranch(id int, quantity int) ([]*pb.Comment, error) {
-	// Get the comment from the database
-	rows, err := c.db.Query(
-		"SELECT * from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC LIMIT (?)",
-		pb.ContentType_COMMENT, id, quantity)
+// Publication date to store, as YYYY-MM-DD text, or NULL when unset
+func dateValue(d *date.Date) any {
+	if d == nil {
+		return nil
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.GetYear(), d.GetMonth(), d.GetDay())
+}
+
+// Scanner of a stored publication date, leaving NULL and empty dates unset
+type dateColumn struct {
+	dest **date.Date
+}
+
+func (c dateColumn) Scan(src any) error {
+	*c.dest = nil
+	var text string
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		// The driver parses datetime columns holding dates, and empty ones
+		// as the zero time
+		if v.IsZero() {
+			return nil
+		}
+		*c.dest = &date.Date{Year: int32(v.Year()), Month: int32(v.Month()), Day: int32(v.Day())}
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("unsupported publication date %T", src)
+	}
+	if text == "" {
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, text[:min(len(text), len(time.DateOnly))])
 	if err != nil {
+		return fmt.Errorf("invalid publication date %q", text)
+	}
+	*c.dest = &date.Date{Year: int32(t.Year()), Month: int32(t.Month()), Day: int32(t.Day())}
+	return nil
+}
+
+// Columns of the post table in the order scanPost reads them
+const postColumns = "id, title, content, subRedditID, videoURL, imageURL, authorID, score, state, publicationDate"
+
+// Columns of the comment table in the order scanComment reads them
+const commentColumns = "id, content, authorID, score, state, publicationDate, parent, parentID"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Scan a post selected with postColumns, leaving out an unset author
+func scanPost(row rowScanner) (*pb.Post, error) {
+	post := &pb.Post{SubReddit: &pb.SubReddit{}}
+	var authorID sql.NullInt32
+	if err := row.Scan(
+		&post.Id, &post.Title, &post.Content, &post.SubReddit.Id,
+		&post.VideoURL, &post.ImageURL, &authorID, &post.Score, &post.State,
+		dateColumn{&post.PublicationDate},
+	); err != nil {
 		return nil, err
 	}
-	comments := []*pb.Comment{}
+	if authorID.Valid {
+		post.Author = &pb.User{Id: authorID.Int32}
+	}
+	return post, nil
+}
 
+func scanPosts(rows *sql.Rows) ([]*pb.Post, error) {
+	defer rows.Close()
+	posts := []*pb.Post{}
 	for rows.Next() {
-		comment := &pb.Comment{
-			Author: &pb.User{},
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
 		}
-		if err := rows.Scan(
-			&comment.Id, &comment.Content, &comment.Author.Id, &comment.Score,
-			&comment.State, &comment.PublicationDate, &comment.Parent, &comment.ParentID,
-		); err != nil {
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// Scan a comment selected with commentColumns, leaving out an unset author
+func scanComment(row rowScanner) (*pb.Comment, error) {
+	comment := &pb.Comment{}
+	var authorID sql.NullInt32
+	if err := row.Scan(
+		&comment.Id, &comment.Content, &authorID, &comment.Score,
+		&comment.State, dateColumn{&comment.PublicationDate}, &comment.Parent, &comment.ParentID,
+	); err != nil {
+		return nil, err
+	}
+	if authorID.Valid {
+		comment.Author = &pb.User{Id: authorID.Int32}
+	}
+	return comment, nil
+}
+
+func scanComments(rows *sql.Rows) ([]*pb.Comment, error) {
+	defer rows.Close()
+	comments := []*pb.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
 			return nil, err
 		}
 		comments = append(comments, comment)
 	}
+	return comments, rows.Err()
+}
 
-	for _, comment := range comments {
-		// Get the replies of the comment
-		rows, err := c.db.Query(
-			"SELECT * from comment WHERE (parent = (?) AND parentID = (?)) ORDER BY score DESC LIMIT (?)",
-			pb.ContentType_COMMENT, comment.Id, quantity)
+// List up to limit posts after the post afterID, of a subreddit or of
+// every subreddit when subRedditID is 0, leaving out hidden posts
+func (c *SQLClient) ListPosts(ctx context.Context, subRedditID int, afterID int, limit int) (_ []*pb.Post, err error) {
+	ctx, end := startQuery(ctx, "ListPosts")
+	defer func() { end(err) }()
+
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT "+postColumns+" FROM post WHERE id > (?) AND ((?) = 0 OR subRedditID = (?)) AND state != (?) ORDER BY id LIMIT (?)",
+		afterID, subRedditID, subRedditID, pb.PostState_HIDDEN_POST, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// Search up to limit posts whose title or content contains query, most
+// upvoted first, leaving out hidden posts
+func (c *SQLClient) SearchPosts(ctx context.Context, query string, subRedditID int, limit int) (_ []*pb.Post, err error) {
+	ctx, end := startQuery(ctx, "SearchPosts")
+	defer func() { end(err) }()
+
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT "+postColumns+" FROM post WHERE (title LIKE (?) ESCAPE '\\' OR content LIKE (?) ESCAPE '\\') AND ((?) = 0 OR subRedditID = (?)) AND state != (?) ORDER BY score DESC, id LIMIT (?)",
+		pattern, pattern, subRedditID, subRedditID, pb.PostState_HIDDEN_POST, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// Escape the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Scan a subreddit, whose tags are stored comma separated
+func scanSubReddit(row rowScanner) (*pb.SubReddit, error) {
+	subReddit := &pb.SubReddit{}
+	var tags sql.NullString
+	if err := row.Scan(&subReddit.Id, &subReddit.Name, &subReddit.State, &tags); err != nil {
+		return nil, err
+	}
+	if tags.String != "" {
+		subReddit.Tags = strings.Split(tags.String, ",")
+	}
+	return subReddit, nil
+}
+
+func (c *SQLClient) GetSubReddit(ctx context.Context, id int) (_ *pb.SubReddit, err error) {
+	ctx, end := startQuery(ctx, "GetSubReddit")
+	defer func() { end(err) }()
+
+	row := c.db.QueryRowContext(ctx, "SELECT id, name, state, tags FROM subreddit WHERE id = (?)", id)
+	return scanSubReddit(row)
+}
+
+// List up to limit subreddits after the subreddit afterID, leaving out
+// hidden subreddits
+func (c *SQLClient) ListSubReddits(ctx context.Context, afterID int, limit int) (_ []*pb.SubReddit, err error) {
+	ctx, end := startQuery(ctx, "ListSubReddits")
+	defer func() { end(err) }()
+
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT id, name, state, tags FROM subreddit WHERE id > (?) AND state != (?) ORDER BY id LIMIT (?)",
+		afterID, pb.SubRedditState_HIDDEN, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	subReddits := []*pb.SubReddit{}
+	for rows.Next() {
+		subReddit, err := scanSubReddit(rows)
 		if err != nil {
 			return nil, err
 		}
-		replies := []*pb.Comment{}
-
-		for rows.Next() {
-			reply := &pb.Comment{
-				Author: &pb.User{},
-			}
-			if err := rows.Scan(
-				&reply.Id, &reply.Content, &reply.Author.Id, &reply.Score,
-				&reply.State, &reply.PublicationDate, &reply.Parent, &reply.ParentID,
-			); err != nil {
-				return nil, err
-			}
-			replies = append(replies, reply)
-		}
-		comment.Children = replies
+		subReddits = append(subReddits, subReddit)
 	}
-
-	return comments, nil
+	return subReddits, rows.Err()
 }
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+)
+
+// Open a private copy of the example database that is removed when the test ends
+func newTestSQLClient(t testing.TB) *SQLClient {
+	data, err := os.ReadFile(filepath.Join("..", db_file))
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "reddit.db")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	client, err := NewSQLClient(file)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.db.Close() })
+	return client
+}
+
+func TestVotes(t *testing.T) {
+	client := newTestSQLClient(t)
+	ctx := context.Background()
+
+	score, err := client.VotePost(ctx, 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, score)
+	score, err = client.VotePost(ctx, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, score)
+	score, err = client.VotePost(ctx, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, score)
+
+	score, err = client.VoteComment(ctx, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, score)
+	comment, err := client.GetComment(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), comment.GetScore())
+}
+
+func TestListPosts(t *testing.T) {
+	client := newTestSQLClient(t)
+	ctx := context.Background()
+
+	posts, err := client.ListPosts(ctx, 0, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, "Cat Video", posts[0].GetTitle())
+	assert.Nil(t, posts[1].GetAuthor())
+
+	posts, err = client.ListPosts(ctx, 0, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, int32(2), posts[0].GetId())
+
+	posts, err = client.ListPosts(ctx, 2, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, int32(2), posts[0].GetSubReddit().GetId())
+}
+
+func TestSearchPosts(t *testing.T) {
+	client := newTestSQLClient(t)
+	ctx := context.Background()
+
+	posts, err := client.SearchPosts(ctx, "video", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "Cat Video", posts[0].GetTitle())
+
+	posts, err = client.SearchPosts(ctx, "cat", 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	// Wildcards are matched literally
+	posts, err = client.SearchPosts(ctx, "%", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+}
+
+func TestSubReddits(t *testing.T) {
+	client := newTestSQLClient(t)
+	ctx := context.Background()
+
+	subReddit, err := client.GetSubReddit(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "r/aww", subReddit.GetName())
+	assert.Equal(t, []string{"tag-1", "tag-2", "tag-3"}, subReddit.GetTags())
+
+	_, err = client.GetSubReddit(ctx, 99)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	subReddits, err := client.ListSubReddits(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, subReddits, 2)
+}
+
+/**
+ *
+ * Benchmarks
+ *
+ */
+
+func BenchmarkCreatePost(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	post := &pb.Post{Title: "Benchmark", Content: "Lorem ipsum", SubReddit: &pb.SubReddit{Id: 1}, Author: &pb.User{Id: 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CreatePost(ctx, post); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVotePost(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.VotePost(ctx, 1, i%2 == 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Votes from concurrent callers, which contend for the database lock
+func BenchmarkVotePostParallel(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			if _, err := client.VotePost(ctx, 1, true); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkGetPost(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetPost(ctx, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPostParallel(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			if _, err := client.GetPost(ctx, 1); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// Top comments of a post with many comments
+func BenchmarkGetTopComments(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		comment := &pb.Comment{Content: "Lorem ipsum", Author: &pb.User{Id: 1}, Score: int32(i % 100), Parent: pb.ContentType_POST, ParentID: 1}
+		_, err := client.CreateComment(ctx, comment)
+		require.NoError(b, err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetTopComments(ctx, 1, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExpandCommentBranch(b *testing.B) {
+	client := newTestSQLClient(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.ExpandCommentBranch(ctx, 1, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
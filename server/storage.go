@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+)
+
+// Storage of subreddits, posts and comments, in SQLite or PostgreSQL.
+// Getters return sql.ErrNoRows for missing rows, which callers report as
+// not found.
+type Storage interface {
+	Ping(ctx context.Context) error
+	Close() error
+
+	CreatePost(ctx context.Context, post *pb.Post) (int, error)
+	VotePost(ctx context.Context, id int, upvote bool) (int, error)
+	GetPost(ctx context.Context, id int) (*pb.Post, error)
+	GetPosts(ctx context.Context, ids []int) ([]*pb.Post, error)
+	ListPosts(ctx context.Context, subRedditID int, afterID int, limit int) ([]*pb.Post, error)
+	SearchPosts(ctx context.Context, query string, subRedditID int, limit int) ([]*pb.Post, error)
+
+	CreateComment(ctx context.Context, comment *pb.Comment) (int, error)
+	VoteComment(ctx context.Context, id int, upvote bool) (int, error)
+	GetComment(ctx context.Context, id int) (*pb.Comment, error)
+	GetComments(ctx context.Context, ids []int) ([]*pb.Comment, error)
+	GetTopComments(ctx context.Context, postID int, quantity int) ([]*pb.Comment, error)
+	ExpandCommentBranch(ctx context.Context, id int, quantity int) ([]*pb.Comment, error)
+
+	GetSubReddit(ctx context.Context, id int) (*pb.SubReddit, error)
+	ListSubReddits(ctx context.Context, afterID int, limit int) ([]*pb.SubReddit, error)
+
+	// Create the tables, or migrate them to the latest version
+	CreateSchema(ctx context.Context) error
+	Export(ctx context.Context, w recordWriter) error
+	Restore(ctx context.Context, r recordReader) (int, error)
+}
+
+var (
+	_ Storage = (*SQLClient)(nil)
+	_ Storage = (*PostgresClient)(nil)
+)
+
+// Open the storage of a backend, the SQLite file or the PostgreSQL database
+// of the connection URL
+func openStorage(backend string, file string, url string) (Storage, error) {
+	switch backend {
+	case "sqlite":
+		c, err := NewSQLClient(file)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	case "postgres":
+		c, err := NewPostgresClient(url)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q, want sqlite or postgres", backend)
+	}
+}
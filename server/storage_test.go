@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+)
+
+// Start a PostgreSQL server of its own for the test, holding the example
+// database. The initdb and postgres binaries are looked up in $POSTGRES_BIN,
+// then on the PATH. The test is skipped without them, unless $POSTGRES_BIN
+// is set as it is in CI.
+func newTestPostgres(t *testing.T) *PostgresClient {
+	binary := func(name string) string {
+		if dir := os.Getenv("POSTGRES_BIN"); dir != "" {
+			return filepath.Join(dir, name)
+		}
+		path, err := exec.LookPath(name)
+		if err != nil {
+			t.Skipf("PostgreSQL is not installed: %v", err)
+		}
+		return path
+	}
+	initdb, postgres := binary("initdb"), binary("postgres")
+
+	// Unix socket paths are short, unlike those of t.TempDir
+	dir, err := os.MkdirTemp("", "pg")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	data := filepath.Join(dir, "data")
+	out, err := exec.Command(initdb, "-D", data, "-U", "postgres", "--auth=trust", "--no-sync").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+	server := exec.Command(postgres, "-D", data, "-k", dir, "-p", fmt.Sprint(port), "-c", "listen_addresses=", "-c", "fsync=off")
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		server.Process.Signal(os.Interrupt)
+		server.Wait()
+	})
+
+	client, err := NewPostgresClient(fmt.Sprintf("postgres://postgres@/postgres?host=%s&port=%d", dir, port))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+	require.Eventually(t, func() bool { return client.Ping(ctx) == nil }, 10*time.Second, 50*time.Millisecond)
+
+	var export strings.Builder
+	w, err := newRecordWriter(&export, formatNDJSON)
+	require.NoError(t, err)
+	require.NoError(t, newTestSQLClient(t).Export(ctx, w))
+	r, err := newRecordReader(strings.NewReader(export.String()), formatNDJSON)
+	require.NoError(t, err)
+	_, err = client.Restore(ctx, r)
+	require.NoError(t, err)
+	return client
+}
+
+func TestStorage(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) { testStorage(t, newTestSQLClient(t)) })
+	t.Run("postgres", func(t *testing.T) { testStorage(t, newTestPostgres(t)) })
+	t.Run("cached", func(t *testing.T) { testStorage(t, newCachedStorage(newTestSQLClient(t), 100, time.Minute)) })
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	client := newTestPostgres(t)
+	ctx := context.Background()
+
+	// Migrating again applies nothing
+	require.NoError(t, client.CreateSchema(ctx))
+	var migrations int
+	require.NoError(t, client.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&migrations))
+	entries, err := postgresMigrations.ReadDir("migrations/postgres")
+	require.NoError(t, err)
+	assert.Equal(t, len(entries), migrations)
+}
+
+// Behaviour every backend shares, on storage holding the example database
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	t.Run("posts", func(t *testing.T) {
+		post, err := s.GetPost(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Cat Video", post.GetTitle())
+		assert.Equal(t, int32(1), post.GetAuthor().GetId())
+
+		post, err = s.GetPost(ctx, 2)
+		require.NoError(t, err)
+		assert.Nil(t, post.GetAuthor())
+		assert.Equal(t, pb.PostState_LOCKED_POST, post.GetState())
+
+		_, err = s.GetPost(ctx, 99)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		posts, err := s.ListPosts(ctx, 2, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, int32(2), posts[0].GetId())
+
+		posts, err = s.SearchPosts(ctx, "CAT", 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, posts, 2)
+		posts, err = s.SearchPosts(ctx, "%", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		comment, err := s.GetComment(ctx, 6)
+		require.NoError(t, err)
+		assert.Equal(t, pb.CommentState_LOCKED_COMMENT, comment.GetState())
+
+		_, err = s.GetComment(ctx, 99)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		// Ties are broken by ID
+		comments, err := s.GetTopComments(ctx, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		assert.Equal(t, []int32{4, 5}, []int32{comments[0].GetId(), comments[1].GetId()})
+
+		branch, err := s.ExpandCommentBranch(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, branch, 1)
+		assert.Equal(t, int32(2), branch[0].GetId())
+		require.Len(t, branch[0].GetChildren(), 1)
+		assert.Equal(t, int32(3), branch[0].GetChildren()[0].GetId())
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		posts, err := s.GetPosts(ctx, []int{2, 99, 1})
+		require.NoError(t, err)
+		require.Len(t, posts, 2)
+		assert.ElementsMatch(t, []int32{1, 2}, []int32{posts[0].GetId(), posts[1].GetId()})
+
+		comments, err := s.GetComments(ctx, []int{6, 3})
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		assert.ElementsMatch(t, []int32{3, 6}, []int32{comments[0].GetId(), comments[1].GetId()})
+
+		comments, err = s.GetComments(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, comments)
+	})
+
+	t.Run("subreddits", func(t *testing.T) {
+		subReddit, err := s.GetSubReddit(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "r/funny", subReddit.GetName())
+		assert.Equal(t, []string{"tag-4", "tag-5", "tag-6"}, subReddit.GetTags())
+
+		subReddits, err := s.ListSubReddits(ctx, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, subReddits, 2)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		videoURL := "https://example.com/video.mp4"
+		want := &pb.Post{
+			Title:     "Dog Video",
+			Content:   "Lorem ipsum",
+			SubReddit: &pb.SubReddit{Id: 1},
+			VideoURL:  &videoURL,
+			Author:    &pb.User{Id: 2},
+			State:     pb.PostState_NORMAL_POST,
+		}
+		id, err := s.CreatePost(ctx, want)
+		require.NoError(t, err)
+		// Restored IDs are not handed out again
+		assert.Equal(t, 3, id)
+		post, err := s.GetPost(ctx, id)
+		require.NoError(t, err)
+		want.Id = int32(id)
+		assert.Equal(t, want.GetTitle(), post.GetTitle())
+		assert.Equal(t, videoURL, post.GetVideoURL())
+		assert.Equal(t, int32(2), post.GetAuthor().GetId())
+
+		id, err = s.CreateComment(ctx, &pb.Comment{Content: "Woof", Author: &pb.User{Id: 1}, Parent: pb.ContentType_POST, ParentID: want.Id})
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+		comments, err := s.GetTopComments(ctx, int(want.Id), 10)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		assert.Equal(t, "Woof", comments[0].GetContent())
+	})
+
+	t.Run("concurrent votes", func(t *testing.T) {
+		const voters = 20
+		var wg sync.WaitGroup
+		for i := 0; i < voters; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := s.VotePost(ctx, 1, true)
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := s.VoteComment(ctx, 1, false)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		post, err := s.GetPost(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2+voters), post.GetScore())
+		comment, err := s.GetComment(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2-voters), comment.GetScore())
+	})
+}
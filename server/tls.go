@@ -0,0 +1,167 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Return the most recent modification time among the given files
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// Key pair that is reloaded from disk whenever the files change, so
+// certificates can be rotated without restarting the server
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile string, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Load the key pair if the files changed since the last load
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	if r.cert != nil && modTime.Equal(r.modTime) {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	// Keep serving the previous certificate while a rotation is half written
+	if err := r.reload(); err != nil {
+		slog.Error("Error reloading TLS key pair", slog.Any("error", err))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, nil
+}
+
+// CA bundle that is reloaded from disk whenever the file changes
+type caReloader struct {
+	caFile string
+
+	mu      sync.Mutex
+	pool    *x509.CertPool
+	modTime time.Time
+}
+
+func newCAReloader(caFile string) (*caReloader, error) {
+	r := &caReloader{caFile: caFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Load the CA bundle if the file changed since the last load
+func (r *caReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTime, err := latestModTime(r.caFile)
+	if err != nil {
+		return err
+	}
+	if r.pool != nil && modTime.Equal(r.modTime) {
+		return nil
+	}
+	pool, err := loadCertPool(r.caFile)
+	if err != nil {
+		return err
+	}
+	r.pool = pool
+	r.modTime = modTime
+	return nil
+}
+
+func (r *caReloader) CertPool() *x509.CertPool {
+	if err := r.reload(); err != nil {
+		slog.Error("Error reloading TLS client CA", slog.Any("error", err))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pool
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return pool, nil
+}
+
+// Build the server TLS configuration. Client certificates are required and
+// verified against clientCAFile when it is set (mutual TLS).
+func newServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certs.GetCertificate,
+		// gRPC and the HTTP listener only add these to their own copies,
+		// not to the copies returned for each client below
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if clientCAFile == "" {
+		return config, nil
+	}
+
+	clientCAs, err := newCAReloader(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c := config.Clone()
+		c.ClientCAs = clientCAs.CertPool()
+		c.GetConfigForClient = nil
+		return c, nil
+	}
+	return config, nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Self-signed certificate authority used to issue test certificates
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// Issue a leaf certificate and return its PEM encoded cert and key
+func (ca *testCA) issue(t *testing.T, commonName string, serial int64) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// Serve TLS handshakes with the given config until the test ends
+func serveTLS(t *testing.T, config *tls.Config) string {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if err := conn.(*tls.Conn).Handshake(); err == nil {
+					conn.Write([]byte{0})
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// Dial the server and return the serial number of the certificate it presented
+func handshake(addr string, config *tls.Config) (*big.Int, error) {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// The server only reports a rejected client certificate after the handshake
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber, nil
+}
+
+func TestServerTLSCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	cert, key := ca.issue(t, "localhost", 100)
+	modTime := time.Now().Add(-time.Minute)
+	writeFile(t, certFile, cert, modTime)
+	writeFile(t, keyFile, key, modTime)
+
+	config, err := newServerTLSConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+	addr := serveTLS(t, config)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+
+	serial, err := handshake(addr, clientConfig)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), serial.Int64())
+
+	// Rotate the certificate on disk
+	cert, key = ca.issue(t, "localhost", 200)
+	writeFile(t, certFile, cert, modTime.Add(time.Second))
+	writeFile(t, keyFile, key, modTime.Add(time.Second))
+
+	serial, err = handshake(addr, clientConfig)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), serial.Int64())
+}
+
+func TestServerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	cert, key := ca.issue(t, "localhost", 100)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	writeFile(t, caFile, ca.pem, time.Now())
+
+	config, err := newServerTLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	addr := serveTLS(t, config)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	// Without a client certificate the handshake is rejected
+	_, err = handshake(addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	assert.Error(t, err)
+
+	// A client certificate issued by the CA is accepted
+	clientCert, clientKey := ca.issue(t, "alice", 300)
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	_, err = handshake(addr, &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{pair}})
+	assert.NoError(t, err)
+}
+
+func TestServerMutualTLSProtocols(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	cert, key := ca.issue(t, "localhost", 100)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	writeFile(t, caFile, ca.pem, time.Now())
+
+	// Both listeners share the config, as they do in main
+	config, err := newServerTLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCert, clientKey := ca.issue(t, "alice", 300)
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{pair}}
+
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	pb.RegisterRedditServer(gs, &gRPCserver{storage: newTestSQLClient(t)})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	var p peer.Peer
+	resp, err := pb.NewRedditClient(conn).GetPost(context.Background(), &pb.GetPostRequest{PostID: 1}, grpc.Peer(&p))
+	require.NoError(t, err)
+	assert.Equal(t, "Cat Video", resp.GetPost().GetTitle())
+	state := p.AuthInfo.(credentials.TLSInfo).State
+	assert.Equal(t, "h2", state.NegotiatedProtocol)
+	require.NotEmpty(t, state.PeerCertificates)
+
+	httpServer := &http.Server{
+		Handler:           http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		TLSConfig:         config,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	lis, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go httpServer.ServeTLS(lis, "", "")
+	t.Cleanup(func() { httpServer.Close() })
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig, ForceAttemptHTTP2: true}}
+	httpResp, err := client.Get("https://" + lis.Addr().String())
+	require.NoError(t, err)
+	httpResp.Body.Close()
+	assert.Equal(t, 2, httpResp.ProtoMajor)
+}
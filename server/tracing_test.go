@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q", name)
+	return nil
+}
+
+func TestTracingPropagatesToStorage(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// Serve the Reddit service over an in-memory connection
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(tp))))
+	pb.RegisterRedditServer(gs, &gRPCserver{storage: newTestSQLClient(t)})
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(tp))),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	// Call the service under a caller span
+	ctx, span := tp.Tracer("test").Start(context.Background(), "demoFunc")
+	_, err = pb.NewRedditClient(conn).GetPost(ctx, &pb.GetPostRequest{PostID: 1})
+	require.NoError(t, err)
+	span.End()
+	gs.Stop()
+
+	spans := recorder.Ended()
+	caller := findSpan(t, spans, "demoFunc")
+	var client, server sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		if s.Name() != "reddit.Reddit/GetPost" {
+			continue
+		}
+		if s.SpanKind() == trace.SpanKindClient {
+			client = s
+		} else {
+			server = s
+		}
+	}
+	require.NotNil(t, client)
+	require.NotNil(t, server)
+	query := findSpan(t, spans, "SQLClient.GetPost")
+
+	// The spans form a single trace from the caller down to the query
+	assert.Equal(t, caller.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, server.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, caller.SpanContext().TraceID(), query.SpanContext().TraceID())
+	assert.Contains(t, query.Attributes(), attribute.String("db.operation", "GetPost"))
+}
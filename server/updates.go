@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/tomy0000000/grpc-reddit/reddit/reddit"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Comment line keeping idle event streams open through proxies
+	sseKeepAlive = 15 * time.Second
+)
+
+// Build the subscriptions for the post and comment query parameters
+func monitorRequests(query map[string][]string) ([]*pb.MonitorUpdatesRequest, error) {
+	requests := []*pb.MonitorUpdatesRequest{}
+	for param, contentType := range map[string]pb.ContentType{"post": pb.ContentType_POST, "comment": pb.ContentType_COMMENT} {
+		for _, value := range query[param] {
+			id, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid %s %q", param, value)
+			}
+			requests = append(requests, &pb.MonitorUpdatesRequest{ContentType: contentType, ContentID: int32(id)})
+		}
+	}
+	return requests, nil
+}
+
+// Open a MonitorUpdates stream on behalf of the HTTP caller and subscribe
+// to the initial contents
+func (g *gateway) openMonitorStream(ctx context.Context, requests []*pb.MonitorUpdatesRequest) (pb.Reddit_MonitorUpdatesClient, error) {
+	stream, err := pb.NewRedditClient(g.conn).MonitorUpdates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	return stream, nil
+}
+
+// Stream score updates of the posts and comments given as post and comment
+// query parameters, as server-sent events when the caller accepts them or
+// else as one JSON object per line
+func (g *gateway) watchUpdates(w http.ResponseWriter, r *http.Request) {
+	requests, err := monitorRequests(r.URL.Query())
+	if err != nil {
+		writeError(w, nil, err)
+		return
+	}
+	if len(requests) == 0 {
+		writeError(w, nil, status.Error(codes.InvalidArgument, "no post or comment to monitor"))
+		return
+	}
+	ctx, cancel := context.WithCancel(outgoingContext(r))
+	defer cancel()
+	stream, err := pb.NewRedditClient(g.conn).WatchUpdates(ctx, &pb.WatchUpdatesRequest{Subscriptions: requests})
+	if err != nil {
+		writeError(w, nil, err)
+		return
+	}
+
+	var events streamWriter = &ndjsonWriter{w: w}
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		events = &sseWriter{w: w}
+	}
+	w.Header().Set("Content-Type", events.contentType())
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	// Receive in the background so idle event streams can be kept alive
+	updates := make(chan *pb.MonitorUpdatesResponse)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case update := <-updates:
+			if err := events.update(update); err != nil {
+				return
+			}
+		case err := <-errc:
+			if err != io.EOF && r.Context().Err() == nil {
+				events.fail(err)
+				flush()
+			}
+			return
+		case <-keepAlive.C:
+			if err := events.keepAlive(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flush()
+	}
+}
+
+// Encoding of a stream of updates in an HTTP response
+type streamWriter interface {
+	contentType() string
+	update(*pb.MonitorUpdatesResponse) error
+	fail(error) error
+	keepAlive() error
+}
+
+// Updates as newline delimited JSON, in the same envelope as grpc-gateway
+type ndjsonWriter struct {
+	w io.Writer
+}
+
+func (n *ndjsonWriter) contentType() string {
+	return "application/x-ndjson"
+}
+
+func (n *ndjsonWriter) update(update *pb.MonitorUpdatesResponse) error {
+	data, err := protojson.Marshal(update)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(n.w, "{\"result\":%s}\n", data)
+	return err
+}
+
+func (n *ndjsonWriter) fail(err error) error {
+	data, err := protojson.Marshal(status.Convert(err).Proto())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(n.w, "{\"error\":%s}\n", data)
+	return err
+}
+
+func (n *ndjsonWriter) keepAlive() error {
+	return nil
+}
+
+// Updates as server-sent events
+type sseWriter struct {
+	w io.Writer
+}
+
+func (s *sseWriter) contentType() string {
+	return "text/event-stream"
+}
+
+func (s *sseWriter) update(update *pb.MonitorUpdatesResponse) error {
+	data, err := protojson.Marshal(update)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "event: update\ndata: %s\n\n", data)
+	return err
+}
+
+func (s *sseWriter) fail(err error) error {
+	data, err := protojson.Marshal(status.Convert(err).Proto())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "event: error\ndata: %s\n\n", data)
+	return err
+}
+
+func (s *sseWriter) keepAlive() error {
+	_, err := fmt.Fprint(s.w, ": keep-alive\n\n")
+	return err
+}
+
+// Bridge a WebSocket onto a MonitorUpdates stream. Subscriptions are taken
+// from the post and comment query parameters and from MonitorUpdatesRequest
+// JSON messages sent by the client, and every update is sent back as a
+// MonitorUpdatesResponse JSON message. The socket is closed with a final
+// google.rpc.Status JSON message when the stream fails.
+func (g *gateway) monitorUpdatesWebSocket(w http.ResponseWriter, r *http.Request) {
+	requests, err := monitorRequests(r.URL.Query())
+	if err != nil {
+		writeError(w, nil, err)
+		return
+	}
+
+	websocket.Server{Handshake: func(_ *websocket.Config, r *http.Request) error {
+		if !g.cors.allowRequest(r) {
+			return fmt.Errorf("origin %q not allowed", r.Header.Get("Origin"))
+		}
+		return nil
+	}, Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+		stream, err := g.openMonitorStream(ctx, requests)
+		if err != nil {
+			sendWebSocketError(ws, err)
+			return
+		}
+
+		// Forward subscriptions sent by the client
+		go func() {
+			defer cancel()
+			for {
+				var data []byte
+				if err := websocket.Message.Receive(ws, &data); err != nil {
+					stream.CloseSend()
+					return
+				}
+				req := &pb.MonitorUpdatesRequest{}
+				if err := protojson.Unmarshal(data, req); err != nil {
+					sendWebSocketError(ws, status.Errorf(codes.InvalidArgument, "invalid message: %v", err))
+					return
+				}
+				if err := stream.Send(req); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					sendWebSocketError(ws, err)
+				}
+				return
+			}
+			data, err := protojson.Marshal(update)
+			if err != nil {
+				return
+			}
+			if err := websocket.Message.Send(ws, string(data)); err != nil {
+				return
+			}
+		}
+	}}.ServeHTTP(w, r)
+}
+
+func sendWebSocketError(ws *websocket.Conn, err error) {
+	if data, err := protojson.Marshal(status.Convert(err).Proto()); err == nil {
+		websocket.Message.Send(ws, string(data))
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func TestMonitorUpdatesNDJSON(t *testing.T) {
+	ts := newTestGateway(t)
+
+	resp, err := http.Get(ts.URL + "/v1/updates?post=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	line, err := bufio.NewReader(resp.Body).ReadBytes('\n')
+	require.NoError(t, err)
+	update := map[string]map[string]any{}
+	require.NoError(t, json.Unmarshal(line, &update))
+	assert.Equal(t, "POST", update["result"]["contentType"])
+	assert.Equal(t, float64(1), update["result"]["contentID"])
+}
+
+func TestMonitorUpdatesSSE(t *testing.T) {
+	ts := newTestGateway(t)
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/updates?comment=2", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	event, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: update\n", event)
+	data, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	update := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &update))
+	assert.Equal(t, "COMMENT", update["contentType"])
+	assert.Equal(t, float64(2), update["contentID"])
+}
+
+func TestMonitorUpdatesWebSocket(t *testing.T) {
+	ts := newTestGateway(t)
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/v1/updates/ws"
+	ws, err := websocket.Dial(url, "", ts.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// Subscribe with a client message
+	require.NoError(t, websocket.Message.Send(ws, `{"contentType": "POST", "contentID": 2}`))
+
+	var data string
+	require.NoError(t, websocket.Message.Receive(ws, &data))
+	update := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(data), &update))
+	assert.Equal(t, "POST", update["contentType"])
+	assert.Equal(t, float64(2), update["contentID"])
+}
+
+func TestMonitorUpdatesWithoutSubscription(t *testing.T) {
+	ts := newTestGateway(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/updates", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "no post or comment to monitor", body["message"])
+}